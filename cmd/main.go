@@ -6,7 +6,13 @@ import (
 	"os"
 
 	"github.com/ymakhloufi/pfc/internal/http"
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
+	"github.com/ymakhloufi/pfc/internal/pkg/blz"
+	"github.com/ymakhloufi/pfc/internal/pkg/epc"
 	"github.com/ymakhloufi/pfc/internal/pkg/iban"
+	"github.com/ymakhloufi/pfc/internal/pkg/rf"
+	"github.com/ymakhloufi/pfc/internal/pkg/ukmodulus"
 	"go.uber.org/zap"
 )
 
@@ -18,10 +24,19 @@ func main() {
 		log.Fatalf("failed to initialize logger: %v", err)
 	}
 
-	ibanService := iban.NewService()
-	ibanController := iban.NewController(ibanService, logger)
+	ibanService, err := newIbanService()
+	if err != nil {
+		logger.Fatal("failed to initialize iban service", zap.Error(err))
+	}
+
+	bicService := bic.NewService(ibanService)
+	ibanController := iban.NewController(ibanService, bicService, iban.MaskPolicyDefault, logger)
+	bicController := bic.NewController(bicService, logger)
+	rfController := rf.NewController(rf.NewService(), logger)
 	httpServer := http.NewHttpServer(port, logger, []http.Controller{
 		ibanController,
+		bicController,
+		rfController,
 	})
 
 	logger.Info(fmt.Sprintf("Starting Web Server on port %d", port))
@@ -29,6 +44,111 @@ func main() {
 	logger.Warn("http server stopped", zap.Error(err))
 }
 
+// newIbanService creates the iban service. If the environment variable IBAN_REGISTRY_FILE is set, the SWIFT IBAN
+// Registry export is read from that file instead of using the one embedded into the binary.
+// If BLZ_FILE is set, German account numbers are validated against the Bundesbank bank code file at that path,
+// and German banks are derived from it. Banks of all countries can be listed in the CSV file at BANK_DIRECTORY_FILE.
+// The SEPA schemes those banks are reachable by are read from the EPC register of participants at EPC_REGISTER_FILE.
+// If UK_MODULUS_WEIGHTS_FILE and UK_SORT_CODE_SUBSTITUTIONS_FILE are set, UK sort codes and account numbers are
+// validated using the modulus weight table and the sort code substitution table at those paths.
+// If IBAN_EXPERIMENTAL_COUNTRIES is "true", countries with IBAN-like formats outside of the registry are supported.
+func newIbanService() (*iban.Service, error) {
+	var opts []iban.Option
+	var banks []bankdir.Bank
+
+	if path := os.Getenv("IBAN_REGISTRY_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open iban registry file: %w", err)
+		}
+		defer f.Close()
+
+		opts = append(opts, iban.WithRegistry(f))
+	}
+
+	if level := os.Getenv("IBAN_VALIDATION_LEVEL"); level != "" {
+		opts = append(opts, iban.WithLevel(iban.Level(level)))
+	}
+
+	if os.Getenv("IBAN_EXPERIMENTAL_COUNTRIES") == "true" {
+		opts = append(opts, iban.WithExperimentalCountries())
+	}
+
+	if path := os.Getenv("BLZ_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bundesbank bank code file: %w", err)
+		}
+		defer f.Close()
+
+		dir, err := blz.Parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bundesbank bank code file: %w", err)
+		}
+
+		opts = append(opts, iban.WithBundesbankDirectory(dir))
+		banks = append(banks, bankdir.FromBundesbank(dir)...)
+	}
+
+	if path := os.Getenv("BANK_DIRECTORY_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bank directory file: %w", err)
+		}
+		defer f.Close()
+
+		csvBanks, err := bankdir.ParseCSV(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bank directory file: %w", err)
+		}
+
+		// the generic directory takes precedence over the national ones
+		banks = append(banks, csvBanks...)
+	}
+
+	if len(banks) > 0 {
+		opts = append(opts, iban.WithBankDirectory(bankdir.NewDirectory(banks)))
+	}
+
+	if path := os.Getenv("EPC_REGISTER_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open epc register file: %w", err)
+		}
+		defer f.Close()
+
+		participants, err := epc.ParseCSV(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse epc register file: %w", err)
+		}
+
+		opts = append(opts, iban.WithEPCRegister(epc.NewRegister(participants)))
+	}
+
+	if weightsPath := os.Getenv("UK_MODULUS_WEIGHTS_FILE"); weightsPath != "" {
+		weights, err := os.Open(weightsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open uk modulus weight table: %w", err)
+		}
+		defer weights.Close()
+
+		substitutions, err := os.Open(os.Getenv("UK_SORT_CODE_SUBSTITUTIONS_FILE"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open uk sort code substitution table: %w", err)
+		}
+		defer substitutions.Close()
+
+		checker, err := ukmodulus.Load(weights, substitutions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load uk modulus checking tables: %w", err)
+		}
+
+		opts = append(opts, iban.WithUKModulusChecker(checker))
+	}
+
+	return iban.NewService(opts...)
+}
+
 // newLogger creates a new logger, depending on the environment variable ENVIRONMENT.
 func newLogger() (*zap.Logger, error) {
 	if os.Getenv("ENVIRONMENT") == "dev" {
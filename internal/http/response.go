@@ -0,0 +1,30 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// WriteJSON writes the response as JSON to the http response writer, logging failures with l.
+func WriteJSON(w http.ResponseWriter, l *zap.Logger, response interface{}, status int) {
+	jsonResponse, err := json.Marshal(response)
+	if err != nil {
+		l.Error("failed to marshal response", zap.Error(err))
+		err = fmt.Errorf("failed to marshal response: %w", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		err = fmt.Errorf("failed to write response: %w", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
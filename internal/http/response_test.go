@@ -0,0 +1,46 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name               string
+		response           interface{}
+		status             int
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name:               "response is written as JSON",
+			response:           map[string]bool{"is_valid": true},
+			status:             http.StatusOK,
+			want:               `{"is_valid":true}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "response that cannot be marshalled fails",
+			response:           map[string]interface{}{"is_valid": make(chan int)},
+			status:             http.StatusOK,
+			want:               "failed to marshal response: json: unsupported type: chan int\n",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			WriteJSON(w, zap.NewNop(), tt.response, tt.status)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package bankdir
+
+import "github.com/ymakhloufi/pfc/internal/pkg/blz"
+
+// FromBundesbank imports the banks of a Bundesbank bank code (BLZ) file. German BBANs have no branch code,
+// so every bank code maps to the main record of its bank.
+func FromBundesbank(dir *blz.Directory) []Bank {
+	banks := make([]Bank, 0, len(dir.Banks()))
+	for _, b := range dir.Banks() {
+		banks = append(banks, Bank{
+			CountryCode: "DE",
+			BankCode:    b.BankCode,
+			Name:        b.Name,
+			BIC:         b.BIC,
+			PostalCode:  b.PostalCode,
+			City:        b.City,
+		})
+	}
+
+	return banks
+}
@@ -0,0 +1,19 @@
+package bankdir
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/blz"
+)
+
+func TestFromBundesbank(t *testing.T) {
+	dir := blz.NewDirectory([]blz.Bank{
+		{BankCode: "37040044", IsPaymentProvider: true, Name: "Commerzbank", PostalCode: "50447", City: "Köln", BIC: "COBADEFFXXX", CheckMethod: "13"},
+		{BankCode: "37040044", IsPaymentProvider: false, Name: "Commerzbank Filiale", PostalCode: "53111", City: "Bonn", CheckMethod: "13"},
+	})
+
+	require.Equal(t, []Bank{
+		{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank", BIC: "COBADEFFXXX", PostalCode: "50447", City: "Köln"},
+	}, FromBundesbank(dir))
+}
@@ -0,0 +1,82 @@
+package bankdir
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidCSV = errors.New("invalid bank directory csv")
+
+// csvColumns are the columns of the generic CSV format. The header row names the columns, so their order is arbitrary
+// and optional columns may be left out.
+var csvColumns = []struct {
+	name     string
+	required bool
+	field    func(*Bank) *string
+}{
+	{name: "country_code", required: true, field: func(b *Bank) *string { return &b.CountryCode }},
+	{name: "bank_code", required: true, field: func(b *Bank) *string { return &b.BankCode }},
+	{name: "branch_code", field: func(b *Bank) *string { return &b.BranchCode }},
+	{name: "name", required: true, field: func(b *Bank) *string { return &b.Name }},
+	{name: "bic", field: func(b *Bank) *string { return &b.BIC }},
+	{name: "street", field: func(b *Bank) *string { return &b.Street }},
+	{name: "postal_code", field: func(b *Bank) *string { return &b.PostalCode }},
+	{name: "city", field: func(b *Bank) *string { return &b.City }},
+}
+
+// ParseCSV reads banks from a comma separated file with a header row, e.g.
+//
+//	country_code,bank_code,branch_code,name,bic,street,postal_code,city
+//	GB,NWBK,601613,National Westminster Bank,NWBKGB2L,1 Princes Street,EC2R 8BP,London
+func ParseCSV(r io.Reader) ([]Bank, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %s", ErrInvalidCSV, err)
+	}
+
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, column := range csvColumns {
+		if _, ok := indexes[column.name]; column.required && !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSV, column.name)
+		}
+	}
+
+	var banks []Bank
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCSV, err)
+		}
+
+		var bank Bank
+		for _, column := range csvColumns {
+			if i, ok := indexes[column.name]; ok {
+				*column.field(&bank) = strings.TrimSpace(record[i])
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		if bank.CountryCode == "" || bank.BankCode == "" || bank.Name == "" {
+			return nil, fmt.Errorf("%w: line %d: country code, bank code and name are required", ErrInvalidCSV, line)
+		}
+		bank.CountryCode = strings.ToUpper(bank.CountryCode)
+		bank.BIC = strings.ToUpper(bank.BIC)
+
+		banks = append(banks, bank)
+	}
+
+	return banks, nil
+}
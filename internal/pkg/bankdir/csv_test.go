@@ -0,0 +1,79 @@
+package bankdir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []Bank
+		wantErr error
+	}{
+		{
+			name: "success",
+			file: "country_code,bank_code,branch_code,name,bic,street,postal_code,city\n" +
+				"GB,NWBK,601613,National Westminster Bank,nwbkgb2l,1 Princes Street,EC2R 8BP,London\n" +
+				"de,37040044,,\"Commerzbank, Köln\",COBADEFFXXX,,50667,Köln\n",
+			want: []Bank{
+				{
+					CountryCode: "GB",
+					BankCode:    "NWBK",
+					BranchCode:  "601613",
+					Name:        "National Westminster Bank",
+					BIC:         "NWBKGB2L",
+					Street:      "1 Princes Street",
+					PostalCode:  "EC2R 8BP",
+					City:        "London",
+				},
+				{
+					CountryCode: "DE",
+					BankCode:    "37040044",
+					Name:        "Commerzbank, Köln",
+					BIC:         "COBADEFFXXX",
+					PostalCode:  "50667",
+					City:        "Köln",
+				},
+			},
+		},
+		{
+			name: "columns in any order, optional columns left out",
+			file: "Name, Country_Code, Bank_Code\nNational Westminster Bank, GB, NWBK\n",
+			want: []Bank{{CountryCode: "GB", BankCode: "NWBK", Name: "National Westminster Bank"}},
+		},
+		{
+			name:    "fails for missing required column",
+			file:    "country_code,bank_code\nGB,NWBK\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for missing required value",
+			file:    "country_code,bank_code,name\nGB,,National Westminster Bank\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for wrong number of fields",
+			file:    "country_code,bank_code,name\nGB,NWBK\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for empty file",
+			file:    "",
+			wantErr: ErrInvalidCSV,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := ParseCSV(strings.NewReader(tt.file))
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
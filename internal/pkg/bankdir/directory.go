@@ -0,0 +1,73 @@
+package bankdir
+
+// Bank is a bank, or one of its branches, identified by the bank and branch code used within the BBANs of its country.
+type Bank struct {
+	CountryCode string
+	BankCode    string
+	BranchCode  string // empty if the record applies to the whole bank
+	Name        string
+	BIC         string
+	Street      string
+	PostalCode  string
+	City        string
+}
+
+// key identifies a bank or branch within the directory.
+type key struct {
+	countryCode string
+	bankCode    string
+	branchCode  string
+}
+
+// Directory maps the bank and branch codes of BBANs to the banks they identify.
+type Directory struct {
+	banks map[key]Bank
+	// bankCodes maps the branches to the code of their bank, empty if several banks list the branch code.
+	bankCodes map[key]string
+}
+
+// NewDirectory creates a Directory of the banks. If a bank or branch is listed more than once, the last record wins,
+// so directories imported from several sources can be combined in order of their precedence.
+func NewDirectory(banks []Bank) *Directory {
+	d := &Directory{banks: make(map[key]Bank, len(banks)), bankCodes: make(map[key]string)}
+	for _, bank := range banks {
+		d.banks[key{countryCode: bank.CountryCode, bankCode: bank.BankCode, branchCode: bank.BranchCode}] = bank
+
+		if bank.BranchCode == "" {
+			continue
+		}
+		branch := key{countryCode: bank.CountryCode, branchCode: bank.BranchCode}
+		if bankCode, ok := d.bankCodes[branch]; ok && bankCode != bank.BankCode {
+			d.bankCodes[branch] = ""
+			continue
+		}
+		d.bankCodes[branch] = bank.BankCode
+	}
+
+	return d
+}
+
+// Lookup returns the branch identified by the country, bank and branch code. If the directory does not list the branch,
+// the record of the whole bank is returned.
+func (d *Directory) Lookup(countryCode, bankCode, branchCode string) (Bank, bool) {
+	if branchCode != "" {
+		if bank, ok := d.banks[key{countryCode: countryCode, bankCode: bankCode, branchCode: branchCode}]; ok {
+			return bank, true
+		}
+	}
+
+	bank, ok := d.banks[key{countryCode: countryCode, bankCode: bankCode}]
+	return bank, ok
+}
+
+// BankCode returns the code of the bank the branch belongs to, e.g. the bank code of a UK sort code. It fails if
+// the directory does not list the branch, or lists it for more than one bank.
+func (d *Directory) BankCode(countryCode, branchCode string) (string, bool) {
+	bankCode := d.bankCodes[key{countryCode: countryCode, branchCode: branchCode}]
+	return bankCode, bankCode != ""
+}
+
+// Len returns the number of banks and branches in the directory.
+func (d *Directory) Len() int {
+	return len(d.banks)
+}
@@ -0,0 +1,86 @@
+package bankdir
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectory_Lookup(t *testing.T) {
+	bank := Bank{CountryCode: "GB", BankCode: "NWBK", Name: "National Westminster Bank", BIC: "NWBKGB2L"}
+	branch := Bank{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", Name: "National Westminster Bank", BIC: "NWBKGB2L", City: "London"}
+	dir := NewDirectory([]Bank{
+		bank,
+		branch,
+		{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank"},
+		{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank Köln", BIC: "COBADEFFXXX"}, // last record wins
+	})
+
+	tests := []struct {
+		name        string
+		countryCode string
+		bankCode    string
+		branchCode  string
+		want        Bank
+		wantOK      bool
+	}{
+		{name: "branch", countryCode: "GB", bankCode: "NWBK", branchCode: "601613", want: branch, wantOK: true},
+		{name: "unknown branch falls back to bank", countryCode: "GB", bankCode: "NWBK", branchCode: "123456", want: bank, wantOK: true},
+		{name: "bank", countryCode: "GB", bankCode: "NWBK", want: bank, wantOK: true},
+		{
+			name:        "last record wins",
+			countryCode: "DE",
+			bankCode:    "37040044",
+			want:        Bank{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank Köln", BIC: "COBADEFFXXX"},
+			wantOK:      true,
+		},
+		{name: "bank code of other country", countryCode: "IE", bankCode: "NWBK"},
+		{name: "unknown bank", countryCode: "GB", bankCode: "BUKB", branchCode: "601613"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, ok := dir.Lookup(tt.countryCode, tt.bankCode, tt.branchCode)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	require.Equal(t, 3, dir.Len())
+}
+
+func TestDirectory_BankCode(t *testing.T) {
+	dir := NewDirectory([]Bank{
+		{CountryCode: "GB", BankCode: "NWBK", Name: "National Westminster Bank"},
+		{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", Name: "National Westminster Bank"},
+		{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", Name: "National Westminster Bank", City: "London"},
+		{CountryCode: "GB", BankCode: "BARC", BranchCode: "200000", Name: "Barclays Bank"},
+		{CountryCode: "GB", BankCode: "BUKB", BranchCode: "200000", Name: "Barclays Bank UK"},
+	})
+
+	tests := []struct {
+		name        string
+		countryCode string
+		branchCode  string
+		want        string
+		wantOK      bool
+	}{
+		{name: "branch", countryCode: "GB", branchCode: "601613", want: "NWBK", wantOK: true},
+		{name: "branch of several banks", countryCode: "GB", branchCode: "200000"},
+		{name: "branch of other country", countryCode: "IE", branchCode: "601613"},
+		{name: "unknown branch", countryCode: "GB", branchCode: "123456"},
+		{name: "whole bank record", countryCode: "GB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, ok := dir.BankCode(tt.countryCode, tt.branchCode)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
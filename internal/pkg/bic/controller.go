@@ -0,0 +1,114 @@
+package bic
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	server "github.com/ymakhloufi/pfc/internal/http"
+	"go.uber.org/zap"
+)
+
+var (
+	_ server.Controller = Controller{}
+
+	validateEndpointRegexp = regexp.MustCompile(`^/v1/bic/([^/?]+)/validate/?$`)
+)
+
+// Parser can parse a bic string into a BIC struct and validate it.
+type Parser interface {
+	Parse(bic string) (BIC, error)
+	Validate(BIC) error
+}
+
+// Controller the bic controller that adds routes to the http server.
+type Controller struct {
+	parser Parser
+	logger *zap.Logger
+}
+
+func NewController(parser Parser, logger *zap.Logger) *Controller {
+	return &Controller{
+		parser: parser,
+		logger: logger,
+	}
+}
+
+// SetupRoutes adds the routes to the http server.
+func (ctrl Controller) SetupRoutes() {
+	// handles all routes prefixed with /bic/ (needed to handle non-query route-params)
+	http.HandleFunc("/v1/bic/", func(w http.ResponseWriter, r *http.Request) {
+		// Add sub-routes as new "cases" here.
+		switch path := r.URL.Path; {
+		case r.Method == http.MethodGet && validateEndpointRegexp.MatchString(path): // /bic/<bic>/validate
+			ctrl.validate(w, r)
+			return
+		default:
+			ctrl.writeResponse(w, newHTTPResponse(nil, fmt.Errorf("unsupported route: %s", path)), http.StatusNotFound)
+		}
+	})
+}
+
+// swagger:operation GET /v1/bic/{bic}/validate validateBIC
+//
+// # Validates a given BIC (ISO 9362) string and returns its validity, components and a possible error message.
+//
+// ---
+// parameters:
+//   - in: path
+//     name: bic
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//    description: BIC was successfully validated, result can be positive or negative
+//    schema:
+//      $ref: '#/definitions/bicHTTPResponse'
+//	'422':
+//    description: BIC string could not be parsed, i.e. is not made up of 8 or 11 letters and digits
+//    schema:
+//      $ref: '#/definitions/bicHTTPResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// validate parses and validates the bic string.
+func (ctrl Controller) validate(w http.ResponseWriter, r *http.Request) {
+	bicStr := validateEndpointRegexp.FindStringSubmatch(r.URL.Path)[1]
+	bicStr = strings.Replace(bicStr, " ", "", -1)
+	bicStr = strings.ToUpper(bicStr)
+
+	bic, err := ctrl.parser.Parse(bicStr)
+	if err != nil {
+		ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = ctrl.parser.Validate(bic)
+	ctrl.writeResponse(w, newHTTPResponse(&bic, err), http.StatusOK) // failed validation is an expected outcome, thus 200.
+}
+
+// newHTTPResponse creates the response for the bic and the error of its processing, if any.
+func newHTTPResponse(bic *BIC, err error) httpResponse {
+	var errStr *string
+	if err != nil {
+		e := err.Error()
+		errStr = &e
+	}
+
+	return httpResponse{Error: errStr, IsValid: err == nil, BIC: bic}
+}
+
+// writeResponse writes the response to the http response writer.
+func (ctrl Controller) writeResponse(w http.ResponseWriter, response httpResponse, status int) {
+	l := ctrl.logger.With(
+		zap.Any("bic", response.BIC),
+		zap.Stringp("error", response.Error),
+		zap.Int("status", status),
+		zap.Any("response", response),
+	)
+
+	server.WriteJSON(w, l, response, status)
+}
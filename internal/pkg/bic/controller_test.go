@@ -0,0 +1,77 @@
+package bic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestController_validate(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/bic/deutdeff500/validate", nil),
+			want: `{"error":null,"is_valid":true,"bic":{"institution_code":"DEUT","country_code":"DE","location_code":"FF","branch_code":"500","is_test_bic":false}}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (BIC, error) {
+					if s != "DEUTDEFF500" {
+						return BIC{}, errors.New("unexpected bic: " + s)
+					}
+					return BIC{InstitutionCode: "DEUT", CountryCode: "DE", LocationCode: "FF", BranchCode: "500"}, nil
+				},
+				ValidateFunc: func(b BIC) error {
+					return nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "parsing error returns 422",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/bic/DEUTDEF/validate", nil),
+			want: `{"error":"parsing error","is_valid":false,"bic":null}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (BIC, error) {
+					return BIC{}, errors.New("parsing error")
+				},
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "validation error returns 200",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/bic/CITIUS33/validate", nil),
+			want: `{"error":"validation error","is_valid":false,"bic":{"institution_code":"CITI","country_code":"US","location_code":"33","is_test_bic":false}}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (BIC, error) {
+					return BIC{InstitutionCode: "CITI", CountryCode: "US", LocationCode: "33"}, nil
+				},
+				ValidateFunc: func(b BIC) error {
+					return errors.New("validation error")
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.validate(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
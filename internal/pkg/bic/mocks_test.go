@@ -0,0 +1,29 @@
+package bic
+
+import "testing"
+
+type mockParser struct {
+	t            *testing.T
+	ParseFunc    func(string) (BIC, error)
+	ValidateFunc func(BIC) error
+}
+
+func (p *mockParser) Parse(s string) (BIC, error) {
+	if p.ParseFunc == nil {
+		p.t.Fatalf("mockParser.ParseFunc: method is nil but Parser.Parse was just called")
+	}
+	return p.ParseFunc(s)
+}
+
+func (p *mockParser) Validate(b BIC) error {
+	if p.ValidateFunc == nil {
+		p.t.Fatalf("mockParser.ValidateFunc: method is nil but Parser.Validate was just called")
+	}
+	return p.ValidateFunc(b)
+}
+
+type countryList map[string]bool
+
+func (l countryList) SupportsCountry(countryCode string) bool {
+	return l[countryCode]
+}
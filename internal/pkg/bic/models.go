@@ -0,0 +1,23 @@
+package bic
+
+// swagger:model
+type BIC struct {
+	InstitutionCode string `json:"institution_code"`
+	CountryCode     string `json:"country_code"`
+	LocationCode    string `json:"location_code"`
+	// BranchCode is empty for 8-character BICs, which identify the primary office like the branch code "XXX".
+	BranchCode string `json:"branch_code,omitempty"`
+	// IsTestBIC is set for BICs that are used for testing and training only, i.e. whose location code ends with "0".
+	IsTestBIC bool `json:"is_test_bic"`
+}
+
+func (b BIC) String() string {
+	return b.InstitutionCode + b.CountryCode + b.LocationCode + b.BranchCode
+}
+
+// swagger:model bicHTTPResponse
+type httpResponse struct {
+	Error   *string `json:"error"`
+	IsValid bool    `json:"is_valid"`
+	BIC     *BIC    `json:"bic"`
+}
@@ -0,0 +1,54 @@
+package bic
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	_ Parser = &Service{}
+
+	// ISO 9362: institution code (4), country code (2), location code (2), optional branch code (3)
+	bicRegexp                  = regexp.MustCompile(`^([A-Z\d]{4})([A-Z]{2})([A-Z\d]{2})([A-Z\d]{3})?$`)
+	ErrIncorrectBicFormat      = fmt.Errorf("provided string does not satisfy the bic format: %s", bicRegexp.String())
+	ErrCountryCodeNotSupported = fmt.Errorf("country code is not supported")
+)
+
+// CountryList reports whether a country is supported, e.g. because there are IBAN rules for it.
+type CountryList interface {
+	SupportsCountry(countryCode string) bool
+}
+
+type Service struct {
+	countries CountryList
+}
+
+// NewService creates a new Service that validates the country codes of BICs against the country list.
+func NewService(countries CountryList) *Service {
+	return &Service{countries: countries}
+}
+
+// Parse parses an 8- or 11-character BIC (ISO 9362) into its components.
+func (svc *Service) Parse(bicStr string) (BIC, error) {
+	matches := bicRegexp.FindStringSubmatch(bicStr)
+	if matches == nil || len(matches) != 5 {
+		return BIC{}, ErrIncorrectBicFormat
+	}
+
+	return BIC{
+		InstitutionCode: matches[1],
+		CountryCode:     matches[2],
+		LocationCode:    matches[3],
+		BranchCode:      matches[4],
+		IsTestBIC:       matches[3][1] == '0',
+	}, nil
+}
+
+// Validate checks that the BIC's country is supported.
+func (svc *Service) Validate(b BIC) error {
+	if !svc.countries.SupportsCountry(b.CountryCode) {
+		return fmt.Errorf("%w: %s", ErrCountryCodeNotSupported, b.CountryCode)
+	}
+
+	return nil
+}
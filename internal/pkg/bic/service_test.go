@@ -0,0 +1,77 @@
+package bic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		bicStr  string
+		want    BIC
+		wantErr error
+	}{
+		{
+			name:   "8 characters",
+			bicStr: "DEUTDEFF",
+			want:   BIC{InstitutionCode: "DEUT", CountryCode: "DE", LocationCode: "FF"},
+		},
+		{
+			name:   "11 characters",
+			bicStr: "DEUTDEFF500",
+			want:   BIC{InstitutionCode: "DEUT", CountryCode: "DE", LocationCode: "FF", BranchCode: "500"},
+		},
+		{
+			name:   "test BIC",
+			bicStr: "NWBKGB20",
+			want:   BIC{InstitutionCode: "NWBK", CountryCode: "GB", LocationCode: "20", IsTestBIC: true},
+		},
+		{
+			name:   "location code ending with other digit",
+			bicStr: "NWBKGB21XXX",
+			want:   BIC{InstitutionCode: "NWBK", CountryCode: "GB", LocationCode: "21", BranchCode: "XXX"},
+		},
+		{name: "too short", bicStr: "DEUTDEF", wantErr: ErrIncorrectBicFormat},
+		{name: "invalid length", bicStr: "DEUTDEFF50", wantErr: ErrIncorrectBicFormat},
+		{name: "too long", bicStr: "DEUTDEFF5000", wantErr: ErrIncorrectBicFormat},
+		{name: "digits in country code", bicStr: "DEUT12FF", wantErr: ErrIncorrectBicFormat},
+		{name: "lowercase", bicStr: "deutdeff", wantErr: ErrIncorrectBicFormat},
+		{name: "empty", bicStr: "", wantErr: ErrIncorrectBicFormat},
+	}
+
+	svc := NewService(countryList{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			got, err := svc.Parse(tt.bicStr)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		bic     BIC
+		wantErr error
+	}{
+		{name: "supported country", bic: BIC{InstitutionCode: "DEUT", CountryCode: "DE", LocationCode: "FF"}},
+		{name: "unsupported country", bic: BIC{InstitutionCode: "CITI", CountryCode: "US", LocationCode: "33"}, wantErr: ErrCountryCodeNotSupported},
+		{name: "unknown country", bic: BIC{InstitutionCode: "DEUT", CountryCode: "XX", LocationCode: "FF"}, wantErr: ErrCountryCodeNotSupported},
+	}
+
+	svc := NewService(countryList{"DE": true, "GB": true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			require.ErrorIs(t, svc.Validate(tt.bic), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,577 @@
+package blz
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownBankCode         = errors.New("unknown bank code")
+	ErrInvalidAccountNumber    = errors.New("account number is invalid for the bank")
+	ErrCheckMethodNotSupported = errors.New("check method is not supported")
+)
+
+// checkMethod validates a 10-digit, zero-padded account number.
+type checkMethod func(account string) bool
+
+// checkMethods are the Bundesbank's account check methods (Prüfzifferberechnungsmethoden), keyed by their code.
+// Together with bankCodeCheckMethods, they cover every published method except E2, E3 and E4, which result in
+// ErrCheckMethodNotSupported like unknown codes do.
+// Ref: https://www.bundesbank.de/en/tasks/payment-systems/services/bank-sort-codes/check-digit-calculation-method
+var checkMethods = map[string]checkMethod{
+	"00": method00,
+	"01": method01,
+	"02": method02,
+	"03": method03,
+	"04": method04,
+	"05": method05,
+	"06": method06,
+	"07": method07,
+	"08": func(a string) bool { return a < "0000060000" || method00(a) },
+	"09": func(a string) bool { return true },
+	"10": method10,
+	"11": func(a string) bool { return mod11(a, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9, 10}, 9) },
+	"13": method13,
+	"14": func(a string) bool { return mod11(a, 4, 9, 10, []int{2, 3, 4, 5, 6, 7}, remainderOneInvalid) },
+	"15": func(a string) bool { return mod11(a, 6, 9, 10, []int{2, 3, 4, 5}, 0) },
+	"16": method16,
+	"17": method17,
+	"18": method18,
+	"19": method19,
+	"20": method20,
+	"21": method21,
+	"22": method22,
+	"23": method23,
+	"24": method24,
+	"25": method25,
+	"26": method26,
+	"27": method27,
+	"28": method28,
+	"29": method29,
+	"30": method30,
+	"31": method31,
+	"32": method32,
+	"33": method33,
+	"34": func(a string) bool { return mod11(a, 1, 7, 8, []int{2, 4, 8, 5, 10, 9, 7}, 0) },
+	"35": method35,
+	"36": func(a string) bool { return mod11(a, 6, 9, 10, []int{2, 4, 8, 5}, 0) },
+	"37": func(a string) bool { return mod11(a, 5, 9, 10, []int{2, 4, 8, 5, 10}, 0) },
+	"38": func(a string) bool { return mod11(a, 4, 9, 10, []int{2, 4, 8, 5, 10, 9}, 0) },
+	"39": func(a string) bool { return mod11(a, 3, 9, 10, []int{2, 4, 8, 5, 10, 9, 7}, 0) },
+	"40": func(a string) bool { return mod11(a, 1, 9, 10, []int{2, 4, 8, 5, 10, 9, 7, 3, 6}, 0) },
+	"41": method41,
+	"42": func(a string) bool { return mod11(a, 2, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9}, 0) },
+	"43": method43,
+	"44": func(a string) bool { return mod11(a, 5, 9, 10, []int{2, 4, 8, 5, 10}, 0) },
+	"45": method45,
+	"46": func(a string) bool { return mod11(a, 3, 7, 8, []int{2, 3, 4, 5, 6}, 0) },
+	"47": func(a string) bool { return mod11(a, 4, 8, 9, []int{2, 3, 4, 5, 6}, 0) },
+	"48": func(a string) bool { return mod11(a, 3, 8, 9, []int{2, 3, 4, 5, 6, 7}, 0) },
+	"49": func(a string) bool { return method00(a) || method01(a) },
+	"50": method50,
+	"51": method51,
+	"54": method54,
+	"55": func(a string) bool { return mod11(a, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 7, 8}, 0) },
+	"56": method56,
+	"57": method57,
+	"58": method58,
+	"59": method59,
+	"60": func(a string) bool { return mod10(a, 3, 9, 10, []int{2, 1}, true) },
+	"61": method61,
+	"62": func(a string) bool { return mod10(a, 3, 7, 8, []int{2, 1}, true) },
+	"63": method63,
+	"64": func(a string) bool { return mod11(a, 1, 6, 7, []int{2, 4, 8, 5, 10, 9}, 0) },
+	"65": method65,
+	"66": method66,
+	"68": method68,
+	"67": func(a string) bool { return mod10(a, 1, 7, 8, []int{2, 1}, true) },
+	"69": method69,
+	"70": method70,
+	"71": method71,
+	"72": func(a string) bool { return mod10(a, 4, 9, 10, []int{2, 1}, true) },
+	"73": method73,
+	"74": method74,
+	"75": method75,
+	"76": method76,
+	"77": method77,
+	"78": method78,
+	"79": method79,
+	"80": method80,
+	"81": method81,
+	"82": method82,
+	"83": method83,
+	"84": method84,
+	"85": method85,
+	"86": method86,
+	"87": method87,
+	"88": method88,
+	"89": method89,
+	"90": method90,
+	"91": method91,
+	"92": func(a string) bool { return mod10(a, 4, 9, 10, []int{3, 7, 1}, false) },
+	"93": method93,
+	"94": func(a string) bool { return mod10(a, 1, 9, 10, []int{1, 2}, true) },
+	"95": method95,
+	"96": method96,
+	"97": method97,
+	"98": method98,
+	"99": method99,
+	"A0": methodA0,
+	"A1": methodA1,
+	"A2": func(a string) bool { return method00(a) || method04(a) },
+	"A3": func(a string) bool { return method00(a) || method10(a) },
+	"A4": methodA4,
+	"A5": methodA5,
+	"A6": methodA6,
+	"A7": func(a string) bool { return method00(a) || method03(a) },
+	"A8": methodA8,
+	"A9": func(a string) bool { return method01(a) || method06(a) },
+	"B0": methodB0,
+	"B1": func(a string) bool { return method05(a) || method01(a) || method00(a) },
+	"B2": methodB2,
+	"B3": methodB3,
+	"B4": methodB4,
+	"B5": methodB5,
+	"B7": methodB7,
+	"B8": methodB8,
+	"B9": methodB9,
+	"C1": methodC1,
+	"C2": func(a string) bool { return method22(a) || method00(a) },
+	"C3": methodC3,
+	"C4": methodC4,
+	"C5": methodC5,
+	"C6": methodC6,
+	"C7": func(a string) bool { return method63(a) || method06(a) },
+	"C8": func(a string) bool { return method00(a) || method04(a) || method07(a) },
+	"C9": func(a string) bool { return method00(a) || method07(a) },
+	"D0": methodD0,
+	"D1": methodD1,
+	"D2": func(a string) bool { return method95(a) || method00(a) || method68(a) },
+	"D3": func(a string) bool { return method00(a) || method27(a) },
+	"D4": methodD4,
+	"D5": methodD5,
+	"D6": func(a string) bool { return method07(a) || method03(a) || method00(a) },
+	"D7": methodD7,
+	"D8": methodD8,
+	"D9": func(a string) bool { return method00(a) || method10(a) || method18(a) },
+	"E0": methodE0,
+	"E1": methodE1,
+}
+
+// bankCodeCheckMethod validates a 10-digit, zero-padded account number of the bank with the given 8-digit bank code.
+type bankCodeCheckMethod func(bankCode, account string) bool
+
+// bankCodeCheckMethods are the check methods that depend on the bank code, which only Directory.ValidateAccount knows.
+var bankCodeCheckMethods = map[string]bankCodeCheckMethod{
+	"52": method52,
+	"53": method53,
+	"B6": methodB6,
+	"C0": methodC0,
+}
+
+// remainderOneInvalid marks mod11 methods for which a remainder of 1 (i.e. check digit 10) makes the account invalid.
+const remainderOneInvalid = -1
+
+// ValidateAccount validates the account number against the check method of the bank with the given bank code.
+// Account numbers shorter than ten digits are padded with leading zeros.
+func (d *Directory) ValidateAccount(bankCode, accountNumber string) error {
+	bank, ok := d.Lookup(bankCode)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownBankCode, bankCode)
+	}
+
+	return validateAccount(bank.CheckMethod, bankCode, accountNumber)
+}
+
+// ValidateAccount validates the account number against the check method with the given code.
+// Account numbers shorter than ten digits are padded with leading zeros.
+// The methods that depend on the bank code (52, 53, B6 and C0) result in ErrCheckMethodNotSupported,
+// use Directory.ValidateAccount for them instead.
+func ValidateAccount(method, accountNumber string) error {
+	return validateAccount(method, "", accountNumber)
+}
+
+// validateAccount validates the account number against the check method with the given code, passing the bank code
+// to the methods that depend on it. The bank code is empty if it is not known.
+func validateAccount(method, bankCode, accountNumber string) error {
+	if accountNumber == "" || len(accountNumber) > 10 || strings.Trim(accountNumber, "0123456789") != "" {
+		return fmt.Errorf("%w: account number must consist of up to ten digits", ErrInvalidAccountNumber)
+	}
+
+	check, ok := checkMethods[method]
+	if bankCodeCheck, dependsOnBankCode := bankCodeCheckMethods[method]; dependsOnBankCode {
+		if len(bankCode) != 8 || strings.Trim(bankCode, "0123456789") != "" {
+			return fmt.Errorf("%w: %s depends on the bank code", ErrCheckMethodNotSupported, method)
+		}
+		check, ok = func(account string) bool { return bankCodeCheck(bankCode, account) }, true
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCheckMethodNotSupported, method)
+	}
+
+	account := strings.Repeat("0", 10-len(accountNumber)) + accountNumber
+	if !check(account) {
+		return fmt.Errorf("%w: check method %s failed", ErrInvalidAccountNumber, method)
+	}
+
+	return nil
+}
+
+// digit returns the digit at the 1-based position of the account number.
+func digit(account string, position int) int {
+	return int(account[position-1] - '0')
+}
+
+// weightedSum multiplies the digits from position `to` leftwards down to position `from` (1-based, inclusive)
+// with the weights, which are repeated if there are more digits than weights.
+// If crossSum is set, the cross sums of the products are added up instead of the products themselves.
+func weightedSum(account string, from, to int, weights []int, crossSum bool) int {
+	sum := 0
+	for pos, i := to, 0; pos >= from; pos, i = pos-1, i+1 {
+		product := digit(account, pos) * weights[i%len(weights)]
+		if crossSum {
+			product = product/10 + product%10
+		}
+		sum += product
+	}
+
+	return sum
+}
+
+// mod10 validates the check digit at checkPos, which is 10 minus the last digit of the weighted sum (10 becomes 0).
+func mod10(account string, from, to, checkPos int, weights []int, crossSum bool) bool {
+	sum := weightedSum(account, from, to, weights, crossSum)
+	return digit(account, checkPos) == (10-sum%10)%10
+}
+
+// mod11 validates the check digit at checkPos, which is 11 minus the remainder of the weighted sum divided by 11.
+// A remainder of 0 results in check digit 0, a remainder of 1 results in remainderOne,
+// or renders the account number invalid if remainderOne is remainderOneInvalid.
+func mod11(account string, from, to, checkPos int, weights []int, remainderOne int) bool {
+	sum := weightedSum(account, from, to, weights, false)
+
+	var checkDigit int
+	switch remainder := sum % 11; remainder {
+	case 0:
+		checkDigit = 0
+	case 1:
+		if remainderOne == remainderOneInvalid {
+			return false
+		}
+		checkDigit = remainderOne
+	default:
+		checkDigit = 11 - remainder
+	}
+
+	return digit(account, checkPos) == checkDigit
+}
+
+// mod7 validates the check digit at checkPos, which is 7 minus the remainder of the weighted sum divided by 7
+// (7 becomes 0).
+func mod7(account string, from, to, checkPos int, weights []int, crossSum bool) bool {
+	sum := weightedSum(account, from, to, weights, crossSum)
+	return digit(account, checkPos) == (7-sum%7)%7
+}
+
+// m10hTransformations are the rows of the transformation table of the iterated transformation (Modulus 10H),
+// used from right to left, starting with the first row for the digit left of the check digit.
+var m10hTransformations = [4][10]int{
+	{0, 1, 5, 9, 3, 7, 4, 8, 2, 6},
+	{0, 1, 7, 6, 9, 8, 3, 2, 5, 4},
+	{0, 1, 8, 4, 6, 2, 9, 5, 7, 3},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+}
+
+// m10h validates the check digit at position 10, which is 10 minus the last digit of the sum of the transformed
+// digits at positions 1 to 9 (10 becomes 0).
+func m10h(account string) bool {
+	sum := 0
+	for pos, row := 9, 0; pos >= 1; pos, row = pos-1, row+1 {
+		sum += m10hTransformations[row%4][digit(account, pos)]
+	}
+
+	return digit(account, 10) == (10-sum%10)%10
+}
+
+// method00 is Modulus 10 with weights 2,1,2,1,2,1,2,1,2 and cross sums of the products.
+func method00(account string) bool {
+	return mod10(account, 1, 9, 10, []int{2, 1}, true)
+}
+
+// method01 is Modulus 10 with weights 3,7,1,3,7,1,3,7,1.
+func method01(account string) bool {
+	return mod10(account, 1, 9, 10, []int{3, 7, 1}, false)
+}
+
+// method02 is Modulus 11 with weights 2,3,4,5,6,7,8,9,2. A remainder of 1 renders the account number invalid.
+func method02(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9}, remainderOneInvalid)
+}
+
+// method03 is Modulus 10 with weights 2,1,2,1,2,1,2,1,2.
+func method03(account string) bool {
+	return mod10(account, 1, 9, 10, []int{2, 1}, false)
+}
+
+// method04 is Modulus 11 with weights 2,3,4,5,6,7,2,3,4. A remainder of 1 renders the account number invalid.
+func method04(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7}, remainderOneInvalid)
+}
+
+// method05 is Modulus 10 with weights 7,3,1,7,3,1,7,3,1.
+func method05(account string) bool {
+	return mod10(account, 1, 9, 10, []int{7, 3, 1}, false)
+}
+
+// method06 is Modulus 11 with weights 2,3,4,5,6,7,2,3,4. A remainder of 1 results in check digit 0.
+func method06(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7}, 0)
+}
+
+// method07 is Modulus 11 with weights 2,3,4,5,6,7,8,9,10. A remainder of 1 renders the account number invalid.
+func method07(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9, 10}, remainderOneInvalid)
+}
+
+// method10 is Modulus 11 with weights 2,3,4,5,6,7,8,9,10. A remainder of 1 results in check digit 0.
+func method10(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9, 10}, 0)
+}
+
+// method18 is Modulus 10 with weights 3,9,7,1,3,9,7,1,3.
+func method18(account string) bool {
+	return mod10(account, 1, 9, 10, []int{3, 9, 7, 1}, false)
+}
+
+// method19 is Modulus 11 with weights 2,3,4,5,6,7,8,9,1.
+func method19(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9, 1}, 0)
+}
+
+// method20 is Modulus 11 with weights 2,3,4,5,6,7,8,9,3.
+func method20(account string) bool {
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9, 3}, 0)
+}
+
+// method28 is Modulus 11 with weights 2,3,4,5,6,7,8 over positions 1 to 7 and the check digit at position 8.
+func method28(account string) bool {
+	return mod11(account, 1, 7, 8, []int{2, 3, 4, 5, 6, 7, 8}, 0)
+}
+
+// method32 is Modulus 11 with weights 2,3,4,5,6,7 over positions 4 to 9.
+func method32(account string) bool {
+	return mod11(account, 4, 9, 10, []int{2, 3, 4, 5, 6, 7}, 0)
+}
+
+// method33 is Modulus 11 with weights 2,3,4,5,6 over positions 5 to 9.
+func method33(account string) bool {
+	return mod11(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, 0)
+}
+
+// method13 is Modulus 10 with weights 2,1,2,1,2,1 over positions 2 to 7 and the check digit at position 8.
+// If that fails, the account number is checked again with a sub-account number of 00 appended.
+func method13(account string) bool {
+	if mod10(account, 2, 7, 8, []int{2, 1}, true) {
+		return true
+	}
+
+	if !strings.HasPrefix(account, "00") {
+		return false
+	}
+
+	shifted := account[2:] + "00"
+	return mod10(shifted, 2, 7, 8, []int{2, 1}, true)
+}
+
+// method17 is Modulus 11 with weights 1,2,1,2,1,2 (left to right) over positions 2 to 7 and cross sums of the
+// products. The check digit at position 8 is 10 minus the remainder of (sum - 1) divided by 11.
+func method17(account string) bool {
+	weights := []int{1, 2, 1, 2, 1, 2}
+
+	sum := 0
+	for pos := 2; pos <= 7; pos++ {
+		product := digit(account, pos) * weights[pos-2]
+		sum += product/10 + product%10
+	}
+
+	remainder := (sum - 1) % 11
+	checkDigit := 0
+	if remainder != 0 {
+		checkDigit = 10 - remainder
+	}
+
+	return digit(account, 8) == checkDigit
+}
+
+// method21 is Modulus 10 with weights 2,1,2,1,2,1,2,1,2 and cross sums of the products, whose sum is reduced to a
+// single digit by repeatedly building its cross sum. The check digit is 10 minus that digit.
+func method21(account string) bool {
+	sum := weightedSum(account, 1, 9, []int{2, 1}, true)
+	for sum > 9 {
+		sum = sum/10 + sum%10
+	}
+
+	return digit(account, 10) == 10-sum
+}
+
+// method22 is Modulus 10 with weights 3,1,3,1,3,1,3,1,3, of whose products only the last digit is used.
+func method22(account string) bool {
+	weights := []int{3, 1}
+
+	sum := 0
+	for pos, i := 9, 0; pos >= 1; pos, i = pos-1, i+1 {
+		sum += (digit(account, pos) * weights[i%2]) % 10
+	}
+
+	return digit(account, 10) == (10-sum%10)%10
+}
+
+// method26 is Modulus 11 with weights 2,3,4,5,6,7,2 over positions 1 to 7 and the check digit at position 8.
+// Account numbers starting with 00 are shifted two positions to the left first.
+func method26(account string) bool {
+	if strings.HasPrefix(account, "00") {
+		account = account[2:] + "00"
+	}
+
+	return mod11(account, 1, 7, 8, []int{2, 3, 4, 5, 6, 7, 2}, 0)
+}
+
+// method30 is Modulus 10 with weights 2,0,0,0,0,1,2,1,2 (left to right).
+func method30(account string) bool {
+	weights := []int{2, 0, 0, 0, 0, 1, 2, 1, 2}
+
+	sum := 0
+	for pos := 1; pos <= 9; pos++ {
+		sum += digit(account, pos) * weights[pos-1]
+	}
+
+	return digit(account, 10) == (10-sum%10)%10
+}
+
+// method31 is Modulus 11 with weights 9,8,7,6,5,4,3,2,1 (right to left), whose remainder is the check digit.
+// A remainder of 10 renders the account number invalid.
+func method31(account string) bool {
+	remainder := weightedSum(account, 1, 9, []int{9, 8, 7, 6, 5, 4, 3, 2, 1}, false) % 11
+	return remainder != 10 && digit(account, 10) == remainder
+}
+
+// method41 is method 00, except that positions 1 to 3 are ignored if position 4 is a 9.
+func method41(account string) bool {
+	if digit(account, 4) == 9 {
+		return mod10(account, 4, 9, 10, []int{2, 1}, true)
+	}
+
+	return method00(account)
+}
+
+// method43 is Modulus 10 with weights 1,2,3,4,5,6,7,8,9.
+func method43(account string) bool {
+	return mod10(account, 1, 9, 10, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, false)
+}
+
+// method45 is method 00, except that account numbers with a 0 at position 1 or a 1 at position 5 have no check digit.
+func method45(account string) bool {
+	if digit(account, 1) == 0 || digit(account, 5) == 1 {
+		return true
+	}
+
+	return method00(account)
+}
+
+// method50 is Modulus 11 with weights 2,3,4,5,6,7 over positions 1 to 6 and the check digit at position 7.
+// If that fails, the account number is checked again with a three-digit sub-account number of 000 appended.
+func method50(account string) bool {
+	if mod11(account, 1, 6, 7, []int{2, 3, 4, 5, 6, 7}, 0) {
+		return true
+	}
+
+	if !strings.HasPrefix(account, "000") {
+		return false
+	}
+
+	shifted := account[3:] + "000"
+	return mod11(shifted, 1, 6, 7, []int{2, 3, 4, 5, 6, 7}, 0)
+}
+
+// method16 is Modulus 11 with weights 2,3,4,5,6,7,2,3,4. A remainder of 1 renders the account number valid
+// if the digits at positions 9 and 10 are equal.
+func method16(account string) bool {
+	remainder := weightedSum(account, 1, 9, []int{2, 3, 4, 5, 6, 7}, false) % 11
+	if remainder == 1 {
+		return digit(account, 9) == digit(account, 10)
+	}
+
+	return mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7}, 0)
+}
+
+// method23 is method 16 over positions 1 to 6 with the check digit at position 7.
+func method23(account string) bool {
+	remainder := weightedSum(account, 1, 6, []int{2, 3, 4, 5, 6, 7}, false) % 11
+	if remainder == 1 {
+		return digit(account, 6) == digit(account, 7)
+	}
+
+	return mod11(account, 1, 6, 7, []int{2, 3, 4, 5, 6, 7}, 0)
+}
+
+// method24 is Modulus 11 with weights 1,2,3,1,2,3,... from left to right, starting with the first significant digit.
+// A leading 3, 4, 5 or 6 is ignored, as are the first three digits if the account number starts with a 9.
+// Every product plus its weight is divided by 11, and the last digit of the sum of the remainders is the check digit.
+func method24(account string) bool {
+	from := 1
+	switch digit(account, 1) {
+	case 3, 4, 5, 6:
+		from = 2
+	case 9:
+		from = 4
+	}
+	for from < 10 && digit(account, from) == 0 {
+		from++
+	}
+
+	weights := []int{1, 2, 3}
+	sum := 0
+	for pos, i := from, 0; pos <= 9; pos, i = pos+1, i+1 {
+		weight := weights[i%3]
+		sum += (digit(account, pos)*weight + weight) % 11
+	}
+
+	return digit(account, 10) == sum%10
+}
+
+// method25 is Modulus 11 with weights 2,3,4,5,6,7,8,9 over positions 2 to 9. A remainder of 1 results in check
+// digit 0, which is only valid if the digit at position 2 is an 8 or a 9.
+func method25(account string) bool {
+	remainder := weightedSum(account, 2, 9, []int{2, 3, 4, 5, 6, 7, 8, 9}, false) % 11
+	if remainder == 1 {
+		return digit(account, 10) == 0 && (digit(account, 2) == 8 || digit(account, 2) == 9)
+	}
+
+	return mod11(account, 2, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9}, 0)
+}
+
+// method27 is method 00 for account numbers up to 999999999 and the iterated transformation (method 29) otherwise.
+func method27(account string) bool {
+	if digit(account, 1) == 0 {
+		return method00(account)
+	}
+
+	return m10h(account)
+}
+
+// method29 is Modulus 10 with the iterated transformation.
+func method29(account string) bool {
+	return m10h(account)
+}
+
+// method35 is Modulus 11 with weights 2,3,4,5,6,7,8,9,10, whose remainder is the check digit. A remainder of 10
+// renders the account number valid if the digits at positions 9 and 10 are equal.
+func method35(account string) bool {
+	remainder := weightedSum(account, 1, 9, []int{2, 3, 4, 5, 6, 7, 8, 9, 10}, false) % 11
+	if remainder == 10 {
+		return digit(account, 9) == digit(account, 10)
+	}
+
+	return digit(account, 10) == remainder
+}
@@ -0,0 +1,640 @@
+package blz
+
+import (
+	"strconv"
+	"strings"
+)
+
+// method51 checks customer accounts with methods A to D, and general ledger accounts, i.e. those with a 9 at
+// position 3, with generalLedger51.
+// A: Modulus 11 with weights 2,3,4,5,6,7 over positions 4 to 9 (method 32).
+// B: Modulus 11 with weights 2,3,4,5,6 over positions 5 to 9 (method 33).
+// C: Modulus 10 with weights 2,1,2,1,2,1 over positions 4 to 9 and cross sums of the products.
+// D: Modulus 7 with weights 2,3,4,5,6 over positions 5 to 9, which cannot result in a check digit of 7, 8 or 9.
+func method51(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	if method32(account) || method33(account) || mod10(account, 4, 9, 10, []int{2, 1}, true) {
+		return true
+	}
+
+	return digit(account, 10) < 7 && mod7(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, false)
+}
+
+// generalLedger51 checks the general ledger accounts (Sachkonten) of method 51, which several other methods adopt:
+// Modulus 11 with weights 2,3,4,5,6,7,8 over positions 3 to 9, or with weights 2,3,4,5,6,7,8,9,10 over positions
+// 1 to 9 if that fails.
+func generalLedger51(account string) bool {
+	return mod11(account, 3, 9, 10, []int{2, 3, 4, 5, 6, 7, 8}, 0) ||
+		mod11(account, 1, 9, 10, []int{2, 3, 4, 5, 6, 7, 8, 9, 10}, 0)
+}
+
+// method52 checks eight-digit account numbers against the account number of the former ESER system, which is made up
+// of positions 5 to 8 of the bank code, followed by the account type, the check digit and the remaining digits of the
+// account number without leading zeros. Ten-digit account numbers starting with 9 are checked with method 20.
+func method52(bankCode, account string) bool {
+	if digit(account, 1) == 9 {
+		return method20(account)
+	}
+	if significantDigits(account) != 8 {
+		return false
+	}
+
+	return eser(bankCode[4:8] + account[2:4] + strings.TrimLeft(account[4:], "0"))
+}
+
+// method53 is method 52 for nine-digit account numbers. The account number of the former ESER system is made up of
+// positions 5 and 6 of the bank code, the digit at position 3, position 8 of the bank code, the account type at
+// position 2, the check digit at position 4 and the remaining digits without leading zeros.
+func method53(bankCode, account string) bool {
+	if digit(account, 1) == 9 {
+		return method20(account)
+	}
+	if significantDigits(account) != 9 {
+		return false
+	}
+
+	return eser(bankCode[4:6] + account[2:3] + bankCode[7:8] + account[1:2] + account[3:4] + strings.TrimLeft(account[4:], "0"))
+}
+
+// eserWeights are the weights of the digits of an ESER account number, from right to left.
+var eserWeights = []int{2, 4, 8, 5, 10, 9, 7, 3, 6, 1, 2, 4}
+
+// eser validates the check digit at position 6 of an account number of the former ESER system, which has up to
+// twelve digits. Its digits are multiplied with the eserWeights, counting the check digit as 0. The check digit is
+// the factor by which its weight has to be multiplied, so that adding the product to the sum results in a remainder
+// of 10 when divided by 11.
+func eser(number string) bool {
+	sum, checkWeight := 0, 0
+	for pos, i := len(number)-1, 0; pos >= 0; pos, i = pos-1, i+1 {
+		if pos == 5 {
+			checkWeight = eserWeights[i]
+			continue
+		}
+		sum += int(number[pos]-'0') * eserWeights[i]
+	}
+
+	for checkDigit := 0; checkDigit <= 9; checkDigit++ {
+		if (sum+checkDigit*checkWeight)%11 == 10 {
+			return int(number[5]-'0') == checkDigit
+		}
+	}
+
+	return false
+}
+
+// method54 is Modulus 11 with weights 2,3,4,5,6,7,2 over positions 3 to 9 of account numbers starting with 49.
+// A remainder of 0 or 1 renders the account number invalid.
+func method54(account string) bool {
+	if account[:2] != "49" {
+		return false
+	}
+
+	remainder := weightedSum(account, 3, 9, []int{2, 3, 4, 5, 6, 7}, false) % 11
+	return remainder > 1 && digit(account, 10) == 11-remainder
+}
+
+// method56 is Modulus 11 with weights 2,3,4,5,6,7,2,3,4. A remainder of 0 or 1 renders the account number invalid,
+// unless it starts with a 9, for which they result in the check digits 8 and 7.
+func method56(account string) bool {
+	remainder := weightedSum(account, 1, 9, []int{2, 3, 4, 5, 6, 7}, false) % 11
+
+	checkDigit := 11 - remainder
+	if remainder <= 1 {
+		if digit(account, 1) != 9 {
+			return false
+		}
+		checkDigit = 8 - remainder
+	}
+
+	return digit(account, 10) == checkDigit
+}
+
+// method57 checks the account number depending on its first two digits, account numbers starting with 00 are invalid.
+// Variant 1 (51, 55, 61, 64 to 66, 70, 73 to 82, 88, 94 and 95): Modulus 10 with weights 1,2,1,2,1,2,1,2,1 (left to
+// right) and cross sums of the products. Account numbers starting with 777777 or 888888 are not checked.
+// Variant 2 (all others from 32 to 98): like variant 1 over positions 1, 2 and 4 to 10, the check digit is at
+// position 3.
+// Variant 3 (40, 50, 91 and 99): not checked.
+// Variant 4 (01 to 31): positions 3 and 4 must be between 01 and 12, and positions 7 to 9 less than 500. The account
+// number 0185125434 is valid nonetheless.
+func method57(account string) bool {
+	switch prefix := 10*digit(account, 1) + digit(account, 2); {
+	case prefix == 0:
+		return false
+	case prefix <= 31:
+		month := 10*digit(account, 3) + digit(account, 4)
+		return account == "0185125434" || (month >= 1 && month <= 12 && account[6:9] < "500")
+	case prefix == 40 || prefix == 50 || prefix == 91 || prefix == 99:
+		return true
+	case prefix == 51 || prefix == 55 || prefix == 61 || (prefix >= 64 && prefix <= 66) || prefix == 70 ||
+		(prefix >= 73 && prefix <= 82) || prefix == 88 || prefix == 94 || prefix == 95:
+		if strings.HasPrefix(account, "777777") || strings.HasPrefix(account, "888888") {
+			return true
+		}
+		return mod10(account, 1, 9, 10, []int{1, 2}, true)
+	default:
+		return mod10(account[:2]+account[3:]+account[2:3], 1, 9, 10, []int{1, 2}, true)
+	}
+}
+
+// method58 is Modulus 11 with weights 2,3,4,5,6 over positions 5 to 9. A remainder of 1 renders the account number
+// invalid.
+func method58(account string) bool {
+	return mod11(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, remainderOneInvalid)
+}
+
+// method59 is method 00, except that account numbers with fewer than nine digits are not checked.
+func method59(account string) bool {
+	return account[:2] == "00" || method00(account)
+}
+
+// method61 is Modulus 10 with weights 2,1,2,1,2,1,2 (left to right) over positions 1 to 7 and cross sums of the
+// products, with the check digit at position 8. If position 9 is an 8, positions 9 and 10 are included with
+// the weights 1,2.
+func method61(account string) bool {
+	return mod10WithMarker(account, 8)
+}
+
+// method65 is method 61, but includes positions 9 and 10 if position 9 is a 9.
+func method65(account string) bool {
+	return mod10WithMarker(account, 9)
+}
+
+// mod10WithMarker implements methods 61 and 65, which include positions 9 and 10 if position 9 is the marker.
+func mod10WithMarker(account string, marker int) bool {
+	sum := weightedSum(account, 1, 7, []int{2, 1}, true)
+	if digit(account, 9) == marker {
+		sum += digit(account, 9)
+		product := digit(account, 10) * 2
+		sum += product/10 + product%10
+	}
+
+	return digit(account, 8) == (10-sum%10)%10
+}
+
+// method63 is Modulus 10 with weights 2,1,2,1,2,1 over positions 2 to 7 and cross sums of the products, with the
+// check digit at position 8. Account numbers with a leading digit other than 0 are invalid, and account numbers
+// without sub-account number, i.e. starting with 000, are checked over positions 4 to 9 as well.
+func method63(account string) bool {
+	if digit(account, 1) != 0 {
+		return false
+	}
+
+	return mod10(account, 2, 7, 8, []int{2, 1}, true) ||
+		(account[:3] == "000" && mod10(account, 4, 9, 10, []int{2, 1}, true))
+}
+
+// method66 is Modulus 11 with weights 2,3,4,5,6,0,0,7 over positions 2 to 9. A remainder of 0 results in check digit 1
+// and a remainder of 1 in check digit 0. Account numbers with a 9 at position 2 are not checked.
+func method66(account string) bool {
+	if digit(account, 2) == 9 {
+		return true
+	}
+
+	remainder := weightedSum(account, 2, 9, []int{2, 3, 4, 5, 6, 0, 0, 7}, false) % 11
+
+	checkDigit := 11 - remainder
+	switch remainder {
+	case 0:
+		checkDigit = 1
+	case 1:
+		checkDigit = 0
+	}
+
+	return digit(account, 10) == checkDigit
+}
+
+// method68 checks ten-digit account numbers, which must have a 9 at position 4, with Modulus 10 and weights
+// 2,1,2,1,2,1 over positions 4 to 9 and cross sums of the products. Nine-digit account numbers starting with 4 are
+// not checked. All others are checked with method 00 (variant 1), or with positions 3 and 4 left out if that fails
+// (variant 2).
+func method68(account string) bool {
+	switch length := significantDigits(account); {
+	case length == 10:
+		return digit(account, 4) == 9 && mod10(account, 4, 9, 10, []int{2, 1}, true)
+	case length == 9 && digit(account, 2) == 4:
+		return true
+	default:
+		return method00(account) || mod10(account, 1, 9, 10, []int{2, 1, 2, 1, 2, 0, 0, 1}, true)
+	}
+}
+
+// method69 does not check account numbers from 9300000000 to 9399999999. Account numbers from 9700000000 to
+// 9799999999 are checked with the iterated transformation (method 29), all others with method 28 or,
+// if that fails, the iterated transformation.
+func method69(account string) bool {
+	switch account[:2] {
+	case "93":
+		return true
+	case "97":
+		return m10h(account)
+	default:
+		return method28(account) || m10h(account)
+	}
+}
+
+// method70 is method 06, but only positions 4 to 9 are included if position 4 is a 5 or positions 4 and 5 are 69.
+func method70(account string) bool {
+	if digit(account, 4) == 5 || account[3:5] == "69" {
+		return method32(account)
+	}
+
+	return method06(account)
+}
+
+// method71 is Modulus 11 with weights 6,5,4,3,2,1 (left to right) over positions 2 to 7. A remainder of 0 results in
+// check digit 0 and a remainder of 1 in check digit 1.
+func method71(account string) bool {
+	return mod11(account, 2, 7, 10, []int{1, 2, 3, 4, 5, 6}, 1)
+}
+
+// method73 checks general ledger accounts like method 51. Customer accounts are checked with Modulus 10 and weights
+// 2,1,2,1,2,1 over positions 4 to 9 (variant 1), Modulus 10 (variant 2) or Modulus 7 (variant 3) with weights
+// 2,1,2,1,2 over positions 5 to 9, all with cross sums of the products.
+func method73(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return mod10(account, 4, 9, 10, []int{2, 1}, true) ||
+		mod10(account, 5, 9, 10, []int{2, 1}, true) ||
+		mod7(account, 5, 9, 10, []int{2, 1}, true)
+}
+
+// method74 is method 00 for account numbers with at least two digits. Six-digit account numbers are valid as well
+// if their check digit is the difference of the sum to the next half decade.
+func method74(account string) bool {
+	if account[:9] == "000000000" {
+		return false
+	}
+
+	if method00(account) {
+		return true
+	}
+
+	if account[:4] != "0000" || digit(account, 5) == 0 {
+		return false
+	}
+
+	sum := weightedSum(account, 1, 9, []int{2, 1}, true)
+	return digit(account, 10) == (5-sum%5)%5
+}
+
+// method75 is Modulus 10 with weights 2,1,2,1,2 and cross sums of the products. Six- and seven-digit account numbers
+// are checked over positions 5 to 9, nine-digit account numbers over positions 3 to 7 if position 2 is a 9 and
+// over positions 2 to 6 otherwise. Account numbers of other lengths are invalid.
+func method75(account string) bool {
+	switch length := significantDigits(account); {
+	case length == 6 || length == 7:
+		return mod10(account, 5, 9, 10, []int{2, 1}, true)
+	case length == 9 && digit(account, 2) == 9:
+		return mod10(account, 3, 7, 8, []int{2, 1}, true)
+	case length == 9:
+		return mod10(account, 2, 6, 7, []int{2, 1}, true)
+	default:
+		return false
+	}
+}
+
+// method76 is Modulus 11 with weights 2,3,4,5,6,7 over positions 2 to 7, whose remainder is the check digit at
+// position 8. The account type at position 1 must be 0, 4, 6, 7, 8 or 9. If that fails, the account number is
+// checked again with a two-digit sub-account number of 00 appended.
+func method76(account string) bool {
+	check := func(account string) bool {
+		switch digit(account, 1) {
+		case 1, 2, 3, 5:
+			return false
+		}
+
+		remainder := weightedSum(account, 2, 7, []int{2, 3, 4, 5, 6, 7}, false) % 11
+		return remainder != 10 && digit(account, 8) == remainder
+	}
+
+	return check(account) || (account[:2] == "00" && check(account[2:]+"00"))
+}
+
+// method77 is Modulus 11 with weights 1,2,3,4,5 over positions 6 to 10, whose sum must be divisible by 11.
+// If it is not, the weights 5,4,3,4,5 are used instead.
+func method77(account string) bool {
+	return weightedSum(account, 6, 10, []int{1, 2, 3, 4, 5}, false)%11 == 0 ||
+		weightedSum(account, 6, 10, []int{5, 4, 3, 4, 5}, false)%11 == 0
+}
+
+// method78 is method 00, except that eight-digit account numbers are not checked.
+func method78(account string) bool {
+	return significantDigits(account) == 8 || method00(account)
+}
+
+// method79 is Modulus 10 with weights 2,1,2,1,2,1,2,1,2 and cross sums of the products. Account numbers starting with
+// 3 to 8 have the check digit at position 10, account numbers starting with 1, 2 or 9 at position 9.
+// Account numbers starting with 0 are invalid.
+func method79(account string) bool {
+	switch digit(account, 1) {
+	case 0:
+		return false
+	case 1, 2, 9:
+		return mod10(account, 1, 8, 9, []int{2, 1}, true)
+	default:
+		return method00(account)
+	}
+}
+
+// method80 checks general ledger accounts like method 51. Customer accounts are checked with Modulus 10 (variant 1)
+// or Modulus 7 (variant 2) and weights 2,1,2,1,2 over positions 5 to 9 with cross sums of the products.
+func method80(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return mod10(account, 5, 9, 10, []int{2, 1}, true) || mod7(account, 5, 9, 10, []int{2, 1}, true)
+}
+
+// method81 checks general ledger accounts like method 51 and customer accounts with method 32.
+func method81(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return method32(account)
+}
+
+// method82 is method 10 if positions 3 and 4 are 99, and method 33 otherwise.
+func method82(account string) bool {
+	if account[2:4] == "99" {
+		return method10(account)
+	}
+
+	return method33(account)
+}
+
+// method83 checks general ledger accounts, i.e. those with 99 at positions 3 and 4, with Modulus 11 and weights
+// 2,3,4,5,6,7,8 over positions 3 to 9. Customer accounts are checked with method 32 (A), method 33 (B) or Modulus 7
+// with weights 2,3,4,5,6 over positions 5 to 9 (C), which cannot result in a check digit of 7, 8 or 9.
+func method83(account string) bool {
+	if account[2:4] == "99" {
+		return mod11(account, 3, 9, 10, []int{2, 3, 4, 5, 6, 7, 8}, 0)
+	}
+
+	return method32(account) || method33(account) ||
+		(digit(account, 10) < 7 && mod7(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, false))
+}
+
+// method84 checks general ledger accounts like method 51. Customer accounts are checked with method 33 (variant 1),
+// Modulus 7 with weights 2,3,4,5,6 (variant 2) or Modulus 10 with weights 2,1,2,1,2 and cross sums of the products
+// (variant 3) over positions 5 to 9.
+func method84(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return method33(account) ||
+		mod7(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, false) ||
+		mod10(account, 5, 9, 10, []int{2, 1}, true)
+}
+
+// method85 is method 83.
+func method85(account string) bool {
+	return method83(account)
+}
+
+// method86 checks general ledger accounts like method 51. Customer accounts are checked with Modulus 10 and
+// weights 2,1,2,1,2,1 over positions 4 to 9 with cross sums of the products (A), or method 32 (B).
+func method86(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return mod10(account, 4, 9, 10, []int{2, 1}, true) || method32(account)
+}
+
+// method87 checks general ledger accounts like method 51. Customer accounts are checked with the algorithm published
+// by the Bundesbank (A), method 33 (B) or Modulus 7 with weights 2,3,4,5,6 over positions 5 to 9 (C).
+func method87(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return method87A(account) || method33(account) ||
+		(digit(account, 10) < 7 && mod7(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, false))
+}
+
+// method87A is method A of method 87, a transcription of the Bundesbank's reference implementation.
+func method87A(account string) bool {
+	tab1 := []int{0, 4, 3, 2, 6}
+	tab2 := []int{7, 1, 5, 9, 8}
+
+	konto := make([]int, 11) // 1-based
+	for pos := 1; pos <= 10; pos++ {
+		konto[pos] = digit(account, pos)
+	}
+
+	i := 4
+	for i < 10 && konto[i] == 0 {
+		i++
+	}
+
+	c2 := i % 2
+	d2 := 0
+	a5 := 0
+	for ; i < 10; i++ {
+		switch konto[i] {
+		case 0:
+			konto[i] = 5
+		case 1:
+			konto[i] = 6
+		case 5:
+			konto[i] = 10
+		case 6:
+			konto[i] = 1
+		}
+
+		if c2 == d2 {
+			if konto[i] > 5 {
+				if c2 == 0 && d2 == 0 {
+					c2, d2 = 1, 1
+					a5 += 6 - (konto[i] - 6)
+				} else {
+					c2, d2 = 0, 0
+					a5 += konto[i]
+				}
+			} else {
+				if c2 == 0 && d2 == 0 {
+					c2 = 1
+				} else {
+					c2 = 0
+				}
+				a5 += konto[i]
+			}
+		} else {
+			if konto[i] > 5 {
+				if c2 == 0 {
+					c2, d2 = 1, 0
+					a5 += -6 + (konto[i] - 6)
+				} else {
+					c2, d2 = 0, 1
+					a5 -= konto[i]
+				}
+			} else {
+				if c2 == 0 {
+					c2 = 1
+				} else {
+					c2 = 0
+				}
+				a5 -= konto[i]
+			}
+		}
+	}
+
+	for a5 < 0 || a5 > 4 {
+		if a5 > 4 {
+			a5 -= 5
+		} else {
+			a5 += 5
+		}
+	}
+
+	p := tab1[a5]
+	if d2 != 0 {
+		p = tab2[a5]
+	}
+
+	if p == konto[10] {
+		return true
+	}
+
+	if konto[4] == 0 {
+		if p > 4 {
+			p -= 5
+		} else {
+			p += 5
+		}
+		return p == konto[10]
+	}
+
+	return false
+}
+
+// method88 is method 32, or Modulus 11 with weights 2,3,4,5,6,7,8 over positions 3 to 9 if position 3 is a 9.
+func method88(account string) bool {
+	if digit(account, 3) == 9 {
+		return mod11(account, 3, 9, 10, []int{2, 3, 4, 5, 6, 7, 8}, 0)
+	}
+
+	return method32(account)
+}
+
+// method89 checks eight- and nine-digit account numbers with method 10, and seven-digit account numbers with
+// Modulus 11 and weights 2,3,4,5,6,7 over positions 4 to 9 and cross sums of the products, where a remainder of 0
+// or 1 results in check digit 0. Account numbers of other lengths are not checked.
+func method89(account string) bool {
+	switch significantDigits(account) {
+	case 8, 9:
+		return method10(account)
+	case 7:
+		checkDigit := 0
+		if remainder := weightedSum(account, 4, 9, []int{2, 3, 4, 5, 6, 7}, true) % 11; remainder > 1 {
+			checkDigit = 11 - remainder
+		}
+		return digit(account, 10) == checkDigit
+	default:
+		return true
+	}
+}
+
+// method90 checks general ledger accounts, i.e. those with a 9 at position 3, with Modulus 11 and weights
+// 2,3,4,5,6,7,8 over positions 3 to 9 (method F). Customer accounts are valid if any of the methods A to E or G
+// succeeds:
+// A: method 32.
+// B: method 33.
+// C: Modulus 7 with weights 2,3,4,5,6 over positions 5 to 9.
+// D: Modulus 9 with weights 2,3,4,5,6 over positions 5 to 9, the check digit is 9 minus the remainder (9 becomes 0).
+// E: Modulus 10 with weights 2,1,2,1,2 over positions 5 to 9.
+// G: Modulus 7 with weights 2,1,2,1,2,1 over positions 4 to 9.
+func method90(account string) bool {
+	if digit(account, 3) == 9 {
+		return mod11(account, 3, 9, 10, []int{2, 3, 4, 5, 6, 7, 8}, 0)
+	}
+
+	mod9 := weightedSum(account, 5, 9, []int{2, 3, 4, 5, 6}, false) % 9
+	return method32(account) ||
+		method33(account) ||
+		mod7(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, false) ||
+		digit(account, 10) == (9-mod9)%9 ||
+		mod10(account, 5, 9, 10, []int{2, 1}, false) ||
+		mod7(account, 4, 9, 10, []int{2, 1}, false)
+}
+
+// method91 is Modulus 11 over positions 1 to 6 with the check digit at position 7, using the weights 2,3,4,5,6,7
+// (A), 7,6,5,4,3,2 (B), 2,3,4,0,5,6,7,8,9,10 over positions 1 to 10 (C) or 2,4,8,5,10,9 (D).
+func method91(account string) bool {
+	return mod11(account, 1, 6, 7, []int{2, 3, 4, 5, 6, 7}, 0) ||
+		mod11(account, 1, 6, 7, []int{7, 6, 5, 4, 3, 2}, 0) ||
+		mod11(account, 1, 10, 7, []int{2, 3, 4, 0, 5, 6, 7, 8, 9, 10}, 0) ||
+		mod11(account, 1, 6, 7, []int{2, 4, 8, 5, 10, 9}, 0)
+}
+
+// method93 is Modulus 11 (variant 1) or Modulus 7 (variant 2) with weights 2,3,4,5,6 over positions 5 to 9 and
+// the check digit at position 10 if positions 1 to 4 are 0000. Otherwise positions 7 to 10 must be 0000, and the
+// check digit is at position 6.
+func method93(account string) bool {
+	from, to, checkPos := 5, 9, 10
+	if account[:4] != "0000" {
+		if account[6:] != "0000" {
+			return false
+		}
+		from, to, checkPos = 1, 5, 6
+	}
+
+	return mod11(account, from, to, checkPos, []int{2, 3, 4, 5, 6}, 0) ||
+		mod7(account, from, to, checkPos, []int{2, 3, 4, 5, 6}, false)
+}
+
+// method95 is method 06, except that some ranges of account numbers are not checked.
+func method95(account string) bool {
+	switch {
+	case account >= "0000000001" && account <= "0001999999",
+		account >= "0009000000" && account <= "0025999999",
+		account >= "0396000000" && account <= "0499999999",
+		account >= "0700000000" && account <= "0799999999",
+		account >= "0910000000" && account <= "0989999999":
+		return true
+	}
+
+	return method06(account)
+}
+
+// method96 is method 19 or method 00. Account numbers from 0001300000 to 0099399999 are not checked.
+func method96(account string) bool {
+	return method19(account) || method00(account) || (account >= "0001300000" && account <= "0099399999")
+}
+
+// method97 divides the account number without its check digit by 11. The remainder is the check digit,
+// a remainder of 10 results in check digit 0.
+func method97(account string) bool {
+	number, _ := strconv.Atoi(account[:9])
+	return digit(account, 10) == number%11%10
+}
+
+// method98 is Modulus 10 with weights 3,1,7,3,1,7,3 over positions 3 to 9, or method 32 if that fails.
+func method98(account string) bool {
+	return mod10(account, 3, 9, 10, []int{3, 1, 7}, false) || method32(account)
+}
+
+// method99 is method 06, except that account numbers from 0396000000 to 0499999999 are not checked.
+func method99(account string) bool {
+	return (account >= "0396000000" && account <= "0499999999") || method06(account)
+}
+
+// significantDigits returns the number of digits of the account number without leading zeros.
+func significantDigits(account string) int {
+	for pos := 1; pos <= 10; pos++ {
+		if digit(account, pos) != 0 {
+			return 11 - pos
+		}
+	}
+
+	return 0
+}
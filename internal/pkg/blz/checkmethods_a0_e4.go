@@ -0,0 +1,311 @@
+package blz
+
+// methodA0 is Modulus 11 with weights 2,4,8,5,10 over positions 5 to 9. A remainder of 0 or 1 results in
+// check digit 0. Three-digit account numbers are not checked.
+func methodA0(account string) bool {
+	return account[:7] == "0000000" || mod11(account, 5, 9, 10, []int{2, 4, 8, 5, 10}, 0)
+}
+
+// methodA1 is Modulus 10 with weights 2,1,2,1,2,1,2 over positions 3 to 9 and cross sums of the products.
+// Only eight- and ten-digit account numbers are valid.
+func methodA1(account string) bool {
+	if length := significantDigits(account); length != 8 && length != 10 {
+		return false
+	}
+
+	return mod10(account, 3, 9, 10, []int{2, 1}, true)
+}
+
+// methodA4 checks account numbers with 99 at positions 3 and 4 with Modulus 11 and weights 2,3,4,5,6 over
+// positions 5 to 9 (variant 3). Others are checked with Modulus 11 (variant 1) or Modulus 7 (variant 2) and weights
+// 2,3,4,5,6,7 over positions 4 to 9. If those fail, method 93 applies (variant 4).
+func methodA4(account string) bool {
+	if account[2:4] == "99" {
+		if mod11(account, 5, 9, 10, []int{2, 3, 4, 5, 6}, 0) {
+			return true
+		}
+	} else if mod11(account, 4, 9, 10, []int{2, 3, 4, 5, 6, 7}, 0) || mod7(account, 4, 9, 10, []int{2, 3, 4, 5, 6, 7}, false) {
+		return true
+	}
+
+	return method93(account)
+}
+
+// methodA5 is method 00, or method 10 if that fails. Account numbers starting with 9 are only checked with method 00.
+func methodA5(account string) bool {
+	return method00(account) || (digit(account, 1) != 9 && method10(account))
+}
+
+// methodA6 is method 00 if position 2 is an 8, and method 01 otherwise.
+func methodA6(account string) bool {
+	if digit(account, 2) == 8 {
+		return method00(account)
+	}
+
+	return method01(account)
+}
+
+// methodA8 checks general ledger accounts like method 51. Customer accounts are checked with method 32 (variant 1)
+// or Modulus 10 with weights 2,1,2,1,2,1 over positions 4 to 9 and cross sums of the products (variant 2).
+func methodA8(account string) bool {
+	if digit(account, 3) == 9 {
+		return generalLedger51(account)
+	}
+
+	return method32(account) || mod10(account, 4, 9, 10, []int{2, 1}, true)
+}
+
+// methodB0 only allows ten-digit account numbers that do not start with 8. Account numbers with a 1, 2, 3 or 6 at
+// position 8 are not checked, all others are checked with method 06.
+func methodB0(account string) bool {
+	if first := digit(account, 1); first == 0 || first == 8 {
+		return false
+	}
+
+	switch digit(account, 8) {
+	case 1, 2, 3, 6:
+		return true
+	default:
+		return method06(account)
+	}
+}
+
+// methodB2 is method 02 for account numbers starting with 0 to 7, and method 00 otherwise.
+func methodB2(account string) bool {
+	if digit(account, 1) <= 7 {
+		return method02(account)
+	}
+
+	return method00(account)
+}
+
+// methodB3 is method 32 for account numbers starting with 0 to 8, and method 06 otherwise.
+func methodB3(account string) bool {
+	if digit(account, 1) == 9 {
+		return method06(account)
+	}
+
+	return method32(account)
+}
+
+// methodB4 is method 00 for account numbers starting with 9, and method 07 otherwise.
+func methodB4(account string) bool {
+	if digit(account, 1) == 9 {
+		return method00(account)
+	}
+
+	return method07(account)
+}
+
+// methodB5 is method 05, or method 00 if that fails. Account numbers starting with 8 or 9 are only checked with
+// method 05.
+func methodB5(account string) bool {
+	if method05(account) {
+		return true
+	}
+
+	if first := digit(account, 1); first == 8 || first == 9 {
+		return false
+	}
+
+	return method00(account)
+}
+
+// methodB6 is method 20 for account numbers starting with 1 to 9 or with 02691 to 02699, and method 53 otherwise.
+func methodB6(bankCode, account string) bool {
+	if digit(account, 1) != 0 || (account[:4] == "0269" && digit(account, 5) != 0) {
+		return method20(account)
+	}
+
+	return method53(bankCode, account)
+}
+
+// methodB7 is method 01 for account numbers from 0001000000 to 0005999999 and from 0700000000 to 0899999999.
+// All other account numbers are not checked.
+func methodB7(account string) bool {
+	if (account >= "0001000000" && account <= "0005999999") || (account >= "0700000000" && account <= "0899999999") {
+		return method01(account)
+	}
+
+	return true
+}
+
+// methodB8 is method 20, or method 29 if that fails. If both fail, only account numbers from 5100000000 to
+// 5999999999 and from 9010000000 to 9109999999 are valid, as they are not checked.
+func methodB8(account string) bool {
+	if method20(account) || method29(account) {
+		return true
+	}
+
+	return (account >= "5100000000" && account <= "5999999999") || (account >= "9010000000" && account <= "9109999999")
+}
+
+// methodB9 checks eight-digit account numbers with variant 1 and seven-digit account numbers with variant 2.
+// Account numbers of other lengths are invalid. If the calculated check digit does not match, the check digit
+// plus 5 (modulo 10) is checked as well.
+// Variant 1: Modulus 11 with weights 1,3,2,1,3,2,1 over positions 3 to 9, where each product plus its weight is
+// divided by 11 and the last digit of the sum of the remainders is the check digit.
+// Variant 2: Modulus 11 with weights 1,2,3,4,5,6 over positions 4 to 9, where the remainder is the check digit.
+func methodB9(account string) bool {
+	var checkDigit int
+	switch significantDigits(account) {
+	case 8:
+		weights := []int{1, 3, 2}
+		sum := 0
+		for pos, i := 9, 0; pos >= 3; pos, i = pos-1, i+1 {
+			weight := weights[i%3]
+			sum += (digit(account, pos)*weight + weight) % 11
+		}
+		checkDigit = sum % 10
+	case 7:
+		checkDigit = weightedSum(account, 4, 9, []int{1, 2, 3, 4, 5, 6}, false) % 11 % 10
+	default:
+		return false
+	}
+
+	return digit(account, 10) == checkDigit || digit(account, 10) == (checkDigit+5)%10
+}
+
+// methodC0 is method 52 for eight-digit account numbers, or method 20 if that fails. All other account numbers are
+// checked with method 20.
+func methodC0(bankCode, account string) bool {
+	return (significantDigits(account) == 8 && method52(bankCode, account)) || method20(account)
+}
+
+// methodC1 is method 17 for account numbers not starting with 5. Account numbers starting with 5 are checked with
+// Modulus 11 and weights 1,2,1,2,1,2,1,2,1 (left to right) over positions 1 to 9 and cross sums of the products.
+// The check digit is 10 minus the remainder of (sum - 1) divided by 11.
+func methodC1(account string) bool {
+	if digit(account, 1) != 5 {
+		return method17(account)
+	}
+
+	remainder := (weightedSum(account, 1, 9, []int{1, 2}, true) - 1) % 11
+	checkDigit := 0
+	if remainder != 0 {
+		checkDigit = 10 - remainder
+	}
+
+	return digit(account, 10) == checkDigit
+}
+
+// methodC3 is method 58 for account numbers starting with 9, and method 00 otherwise.
+func methodC3(account string) bool {
+	if digit(account, 1) == 9 {
+		return method58(account)
+	}
+
+	return method00(account)
+}
+
+// methodC4 is method 58 for account numbers starting with 9, and method 15 otherwise.
+func methodC4(account string) bool {
+	if digit(account, 1) == 9 {
+		return method58(account)
+	}
+
+	return mod11(account, 6, 9, 10, []int{2, 3, 4, 5}, 0)
+}
+
+// methodC5 checks the account number depending on its length and its first significant digit:
+// six- and nine-digit account numbers starting with 1 to 8 with method 75, ten-digit account numbers starting with
+// 1, 4, 5, 6 or 9 with method 29 and those starting with 3 with method 00. Eight-digit account numbers starting with
+// 3, 4 or 5 and ten-digit account numbers starting with 70 or 85 are not checked. All others are invalid.
+func methodC5(account string) bool {
+	length := significantDigits(account)
+	if length == 0 {
+		return false
+	}
+
+	switch first := digit(account, 11-length); {
+	case (length == 6 || length == 9) && first <= 8:
+		return method75(account)
+	case length == 8:
+		return first >= 3 && first <= 5
+	case length == 10 && (first == 1 || first == 4 || first == 5 || first == 6 || first == 9):
+		return method29(account)
+	case length == 10 && first == 3:
+		return method00(account)
+	case length == 10:
+		return account[:2] == "70" || account[:2] == "85"
+	default:
+		return false
+	}
+}
+
+// c6Prefixes are the digits method C6 puts in front of positions 2 to 9, chosen by the first digit.
+var c6Prefixes = [10]string{
+	"4451970", "4451981", "4451992", "4451993", "4344992",
+	"4344990", "4344991", "5499570", "4451994", "5499579",
+}
+
+// methodC6 is method 00 over the digits at positions 2 to 9, prefixed with a constant chosen by the first digit.
+func methodC6(account string) bool {
+	return mod10(c6Prefixes[digit(account, 1)]+account[1:], 1, 15, 16, []int{2, 1}, true)
+}
+
+// methodD0 does not check account numbers starting with 57, and is method 20 otherwise.
+func methodD0(account string) bool {
+	return account[:2] == "57" || method20(account)
+}
+
+// methodD1 is method 00 over the digits at positions 1 to 9, prefixed with 436338. Account numbers starting with 8
+// are invalid.
+func methodD1(account string) bool {
+	return digit(account, 1) != 8 && mod10("436338"+account, 1, 15, 16, []int{2, 1}, true)
+}
+
+// methodD4 is method 00 over the digits at positions 1 to 9, prefixed with 428259. Account numbers starting with 0
+// are invalid.
+func methodD4(account string) bool {
+	return digit(account, 1) != 0 && mod10("428259"+account, 1, 15, 16, []int{2, 1}, true)
+}
+
+// methodD5 checks accounts with 99 at positions 3 and 4 with Modulus 11 and weights 2,3,4,5,6,7,8 over positions
+// 3 to 9 (variant 1). Other accounts are checked with method 32 (variant 2), Modulus 7 with weights 2,3,4,5,6,7
+// (variant 3) or Modulus 10 with weights 2,3,4,5,6,7 (variant 4) over positions 4 to 9.
+func methodD5(account string) bool {
+	if account[2:4] == "99" {
+		return mod11(account, 3, 9, 10, []int{2, 3, 4, 5, 6, 7, 8}, 0)
+	}
+
+	return method32(account) ||
+		mod7(account, 4, 9, 10, []int{2, 3, 4, 5, 6, 7}, false) ||
+		mod10(account, 4, 9, 10, []int{2, 3, 4, 5, 6, 7}, false)
+}
+
+// methodD7 is Modulus 10 with weights 2,1,2,1,2,1,2,1,2 and cross sums of the products, whose last digit is the
+// check digit.
+func methodD7(account string) bool {
+	return digit(account, 10) == weightedSum(account, 1, 9, []int{2, 1}, true)%10
+}
+
+// methodD8 is method 00 for ten-digit account numbers. Account numbers from 0010000000 to 0099999999 are not
+// checked, all others are invalid.
+func methodD8(account string) bool {
+	if digit(account, 1) != 0 {
+		return method00(account)
+	}
+
+	return digit(account, 2) == 0 && digit(account, 3) != 0
+}
+
+// methodE0 is method 00 with a constant of 7 added to the sum.
+func methodE0(account string) bool {
+	sum := weightedSum(account, 1, 9, []int{2, 1}, true) + 7
+	return digit(account, 10) == (10-sum%10)%10
+}
+
+// methodE1 is Modulus 11 with weights 1,2,3,4,5,6,11,10,9 over the ASCII values of the digits at positions 1 to 9,
+// whose remainder is the check digit. A remainder of 10 renders the account number invalid.
+func methodE1(account string) bool {
+	weights := []int{1, 2, 3, 4, 5, 6, 11, 10, 9}
+
+	sum := 0
+	for pos, i := 9, 0; pos >= 1; pos, i = pos-1, i+1 {
+		sum += int(account[pos-1]) * weights[i]
+	}
+
+	remainder := sum % 11
+	return remainder != 10 && digit(account, 10) == remainder
+}
@@ -0,0 +1,312 @@
+package blz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		account string
+		wantErr error
+	}{
+		{name: "method 00 valid", method: "00", account: "0532013000"},
+		{name: "method 00 invalid", method: "00", account: "0532013001", wantErr: ErrInvalidAccountNumber},
+		{name: "method 01 valid", method: "01", account: "0532013008"},
+		{name: "method 01 invalid", method: "01", account: "0532013000", wantErr: ErrInvalidAccountNumber},
+		{name: "method 02 valid", method: "02", account: "0532013050"},
+		{name: "method 02 invalid", method: "02", account: "0532013000", wantErr: ErrInvalidAccountNumber},
+		{name: "method 03 valid", method: "03", account: "0532013000"},
+		{name: "method 04 valid", method: "04", account: "0532013003"},
+		{name: "method 05 valid", method: "05", account: "0532013002"},
+		{name: "method 06 valid", method: "06", account: "0532013003"},
+		{name: "method 06 invalid", method: "06", account: "0532013000", wantErr: ErrInvalidAccountNumber},
+		{name: "method 07 valid", method: "07", account: "0532013050"},
+		{name: "method 08 valid", method: "08", account: "0532013000"},
+		{name: "method 08 is not checked below 60000", method: "08", account: "59999"},
+		{name: "method 09 is never checked", method: "09", account: "1234567890"},
+		{name: "method 10 valid", method: "10", account: "0532013000"},
+		{name: "method 11 valid", method: "11", account: "0532013009"},
+		{name: "method 13 valid", method: "13", account: "0532013000"},
+		{name: "method 13 valid with sub-account", method: "13", account: "0005320130"},
+		{name: "method 13 invalid", method: "13", account: "0532014000", wantErr: ErrInvalidAccountNumber},
+		{name: "method 14 valid", method: "14", account: "0532013002"},
+		{name: "method 15 valid", method: "15", account: "0532013005"},
+		{name: "method 17 valid", method: "17", account: "0532013200"},
+		{name: "method 17 invalid", method: "17", account: "0532013000", wantErr: ErrInvalidAccountNumber},
+		{name: "method 18 valid", method: "18", account: "0532013004"},
+		{name: "method 19 valid", method: "19", account: "0532013000"},
+		{name: "method 20 valid", method: "20", account: "0532013000"},
+		{name: "method 21 valid", method: "21", account: "0532013008"},
+		{name: "method 22 valid", method: "22", account: "0532013004"},
+		{name: "method 26 valid", method: "26", account: "0532013500"},
+		{name: "method 28 valid", method: "28", account: "0532013500"},
+		{name: "method 30 valid", method: "30", account: "0532013003"},
+		{name: "method 31 valid", method: "31", account: "0532013050"},
+		{name: "method 32 valid", method: "32", account: "0532013002"},
+		{name: "method 33 valid", method: "33", account: "0532013005"},
+		{name: "method 34 valid", method: "34", account: "0532013400"},
+		{name: "method 36 valid", method: "36", account: "0532013004"},
+		{name: "method 37 valid", method: "37", account: "0532013004"},
+		{name: "method 38 valid", method: "38", account: "0532013008"},
+		{name: "method 39 valid", method: "39", account: "0532013009"},
+		{name: "method 40 valid", method: "40", account: "0532013005"},
+		{name: "method 41 valid", method: "41", account: "0532013000"},
+		{name: "method 42 valid", method: "42", account: "0532013000"},
+		{name: "method 43 valid", method: "43", account: "0532013004"},
+		{name: "method 44 valid", method: "44", account: "0532013004"},
+		{name: "method 45 valid", method: "45", account: "0532013000"},
+		{name: "method 45 is not checked with leading zero", method: "45", account: "0532013001"},
+		{name: "method 46 valid", method: "46", account: "0532013700"},
+		{name: "method 47 valid", method: "47", account: "0532013080"},
+		{name: "method 48 valid", method: "48", account: "0532013090"},
+		{name: "method 50 valid", method: "50", account: "0532010000"},
+		{name: "method 50 invalid", method: "50", account: "0532013000", wantErr: ErrInvalidAccountNumber},
+		{name: "unsupported method", method: "E4", account: "0532013000", wantErr: ErrCheckMethodNotSupported},
+		{name: "method depending on the bank code", method: "52", account: "43001500", wantErr: ErrCheckMethodNotSupported},
+		{name: "non-numeric account number", method: "00", account: "05320130AB", wantErr: ErrInvalidAccountNumber},
+		{name: "too long account number", method: "00", account: "05320130000", wantErr: ErrInvalidAccountNumber},
+		{name: "empty account number", method: "00", account: "", wantErr: ErrInvalidAccountNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.ErrorIs(t, ValidateAccount(tt.method, tt.account), tt.wantErr)
+		})
+	}
+}
+
+func TestDirectory_ValidateAccount(t *testing.T) {
+	dir := NewDirectory([]Bank{{BankCode: "37040044", IsPaymentProvider: true, CheckMethod: "13"}})
+
+	require.NoError(t, dir.ValidateAccount("37040044", "0532013000"))
+	err := dir.ValidateAccount("37040044", "0532013100")
+	require.ErrorIs(t, err, ErrInvalidAccountNumber)
+	require.NotContains(t, err.Error(), "532013100", "errors end up in the logs, which must not contain account data")
+	require.ErrorIs(t, dir.ValidateAccount("12345678", "0532013000"), ErrUnknownBankCode)
+}
+
+// TestValidateAccount_TestVectors checks the methods against the test account numbers published by the Bundesbank,
+// complemented by account numbers with a wrong check digit. The methods depending on the bank code are checked with
+// the bank code of the test account numbers.
+func TestValidateAccount_TestVectors(t *testing.T) {
+	tests := []struct {
+		method   string
+		bankCode string
+		valid    []string
+		invalid  []string
+	}{
+		{method: "24", valid: []string{"138301", "1306118605", "3307118608", "9307118603"}, invalid: []string{"1306118604", "3307118607"}},
+		{
+			method:  "51",
+			valid:   []string{"0001156071", "0001156136", "0000156078", "0000156071", "0000340968", "0000201178", "0001009588", "0199100002", "0099100010", "2599100002", "0199100004", "2599100003", "3199204090"},
+			invalid: []string{"0099345678", "0099100110", "0199100040"},
+		},
+		{method: "52", bankCode: "13051172", valid: []string{"43001500", "48726458"}, invalid: []string{"82335729", "29837521"}},
+		{method: "53", bankCode: "16052072", valid: []string{"382432256"}, invalid: []string{"382432257"}},
+		{method: "54", valid: []string{"4964137395", "4900010987"}, invalid: []string{"4864137391", "4900010986"}},
+		{
+			method:  "57",
+			valid:   []string{"7500021766", "9400001734", "7800028282", "8100244186", "3251080371", "3891234567", "7777778800", "5001050352", "5045090090", "1909700805", "9322111030"},
+			invalid: []string{"5302707782", "6412121212", "1813499999", "2206735010"},
+		},
+		{method: "58", valid: []string{"1800881120", "9200654108", "1015222224", "3703169668"}, invalid: []string{"0000641406", "0000500007"}},
+		{method: "61", valid: []string{"2063099200", "0260760481"}, invalid: []string{"0260760482"}},
+		{method: "63", valid: []string{"0123456600", "0001234566"}, invalid: []string{"0123456700", "0001234567"}},
+		{method: "66", valid: []string{"100154508", "101154508", "100154516", "101154516"}, invalid: []string{"100154509", "101154509"}},
+		{method: "68", valid: []string{"8889654328", "987654324", "987654328", "400000000"}, invalid: []string{"8889654329", "987654321"}},
+		{method: "69", valid: []string{"9721134869", "1234567900", "1234567006"}, invalid: []string{"1234567008", "1234567007"}},
+		{method: "71", valid: []string{"7101234007"}, invalid: []string{"7101234001"}},
+		{
+			method:  "73",
+			valid:   []string{"0003503398", "0001340967", "0003503391", "0001340968", "0003503392", "0001340966", "0199100002", "0099100010"},
+			invalid: []string{"0003503399", "0001340969", "0099345678"},
+		},
+		{method: "74", valid: []string{"1016", "26260", "242243", "242248", "18002113", "1821200043"}, invalid: []string{"1011", "26265", "18002118", "6160000024"}},
+		{method: "76", valid: []string{"0006543200", "9012345600", "7876543100"}, invalid: []string{"0006543300", "1012345600"}},
+		{method: "77", valid: []string{"10338", "13844", "65354", "69258"}, invalid: []string{"10337", "13843", "65353", "69257"}},
+		{method: "78", valid: []string{"7581499", "9999999981"}, invalid: []string{"7581490", "9999999982"}},
+		{method: "84", valid: []string{"240699", "350982", "461059", "240692", "350985", "461052", "0199100002"}, invalid: []string{"240693", "350986", "0099345678"}},
+		{
+			method:  "87",
+			valid:   []string{"0000000406", "0000051768", "0010701590", "0010720185", "0000100005", "0000393814", "0000950360", "3199500501", "0000100062", "0000100088"},
+			invalid: []string{"0000100084", "0000100085", "0000100089"},
+		},
+		{method: "88", valid: []string{"2525259", "1000500", "90013000", "92525253", "99913003"}, invalid: []string{"2525253", "1000509", "90013003"}},
+		{
+			method:  "90",
+			valid:   []string{"0001975641", "0001988654", "0001863530", "0001784451", "0000654321", "0000677747", "0000840507", "0000996663", "0000666034", "0099100002"},
+			invalid: []string{"0001924592", "0000901568", "0000820487", "0000726393", "0000924591", "0099100007"},
+		},
+		{
+			method:  "91",
+			valid:   []string{"2974118000", "5281741000", "9952810000", "2974117000", "5281770000", "9952812000", "8840019000", "8840050000", "8840087000", "8840045000", "8840012000", "8840055000", "8840080000"},
+			invalid: []string{"8840017000", "8840023000", "8840041000"},
+		},
+		{
+			method:  "93",
+			valid:   []string{"6714790000", "0000671479", "1277830000", "0000127783", "1277910000", "0000127791", "3067540000", "0000306754"},
+			invalid: []string{"6714791000", "0000671470"},
+		},
+		{method: "95", valid: []string{"0068007003", "0847321750", "6450060494", "6454000003"}, invalid: []string{"0068007006", "0847321751"}},
+		{method: "96", valid: []string{"0000254100", "9421000009", "0000000208", "0101115152", "0301204301"}, invalid: []string{"0000254101", "0000000202"}},
+		{method: "97", valid: []string{"24010019"}, invalid: []string{"24010018"}},
+		{method: "98", valid: []string{"9619439213", "3009800016", "9619509976", "5989800173", "9619319999", "6719430018"}, invalid: []string{"9619439211"}},
+		{method: "A0", valid: []string{"521003287", "54500", "3287", "18761", "28290"}, invalid: []string{"521003280", "54501", "3286"}},
+		{method: "A1", valid: []string{"0010030005", "0010030997", "1010030054"}, invalid: []string{"0110030005", "0013030005", "1010030998"}},
+		{method: "A3", valid: []string{"1234567897", "0123456782", "9876543210", "1234567890", "0123456789"}, invalid: []string{"1234567899", "1234567898"}},
+		{
+			method:  "A4",
+			valid:   []string{"0004711173", "0007093330", "0004711172", "0007093335", "1199503010", "8499421235", "0000862342", "8997710000", "0664040000", "0000905844"},
+			invalid: []string{"8623420004", "1299503117", "6099702031"},
+		},
+		{
+			method:  "A5",
+			valid:   []string{"9941510001", "9961230019", "9380027210", "9932290910", "0000251437", "0007948344", "0000159590", "0000051640"},
+			invalid: []string{"9941510002", "9961230020", "0000251438", "0000159591"},
+		},
+		{method: "A6", valid: []string{"800048548", "0855000014", "17", "55300030", "150178033", "600003555", "900291823"}, invalid: []string{"860000817", "810033652", "305888", "200071280"}},
+		{method: "A7", valid: []string{"19010008", "19010438", "19010660", "19010876", "209010892"}, invalid: []string{"209010893"}},
+		{
+			method:  "A8",
+			valid:   []string{"7436661", "7436670", "1359100", "7436660", "7436678", "0003503398", "0001340967", "0199100002", "0099100010", "2599100002", "0199100004"},
+			invalid: []string{"7436666", "7436677", "0003503391", "0001340966", "0099345678"},
+		},
+		{method: "A9", valid: []string{"5043608", "86725", "504360", "822035", "32577083"}, invalid: []string{"86724", "292497", "30767208"}},
+		{
+			method:  "B0",
+			valid:   []string{"1197423162", "1000000606", "1000000406", "1035791538", "1126939724", "1197423460"},
+			invalid: []string{"1000000405", "1035791539", "8035791532", "0535791830", "0000000001"},
+		},
+		{
+			method:  "B1",
+			valid:   []string{"1434253150", "2746315471", "7414398260", "8347251693", "1501824", "1501832"},
+			invalid: []string{"0123456789", "2345678901", "5678901234"},
+		},
+		{
+			method:  "B2",
+			valid:   []string{"0020012357", "0080012345", "0926801910", "1002345674", "8000990054", "9000481805"},
+			invalid: []string{"0020012399", "0080012347", "0926801920", "8000990057"},
+		},
+		{method: "B3", valid: []string{"1000000060", "0140000000", "1000000701", "9635000101", "9730200100"}, invalid: []string{"1000000061", "9635100101", "9730300100"}},
+		{
+			method:  "B4",
+			valid:   []string{"9941510001", "9961230019", "9380027210", "9932290910", "0000251437", "0007948344", "0000051640"},
+			invalid: []string{"9941510002", "9961230020", "0000251438", "0007948345", "0000159590"},
+		},
+		{method: "B5", valid: []string{"0159006955", "2000123451", "1151043216", "9000939033"}, invalid: []string{"0120000001", "5000000003", "9000939034"}},
+		{method: "B6", bankCode: "80053782", valid: []string{"9110000000", "0269876545", "487310018"}, invalid: []string{"9111000000", "0269456780"}},
+		{method: "B6", bankCode: "80053762", invalid: []string{"467310018"}},
+		{method: "B6", bankCode: "80053772", invalid: []string{"477310018"}},
+		{
+			method:  "B7",
+			valid:   []string{"0700001529", "0730000019", "0001001008", "0001057887", "0001007222", "0810011825", "0800107653", "0005922372"},
+			invalid: []string{"0001057886", "0003815570", "0005620516", "0740912243", "0893524479"},
+		},
+		{
+			method:  "B8",
+			valid:   []string{"0734192657", "6932875274", "3145863029", "2938692523", "5011654366", "9086543210"},
+			invalid: []string{"0132572975", "3038752371", "9000412340"},
+		},
+		{
+			method:  "B9",
+			valid:   []string{"87920187", "41203755", "81069577", "61287958", "58467232", "7125633", "1253657", "4353631"},
+			invalid: []string{"2356412", "5435886", "9435414"},
+		},
+		{
+			method:   "C0",
+			bankCode: "13051172",
+			valid:    []string{"43001500", "48726458", "0082335729", "0734192657", "6932875274"},
+			invalid:  []string{"0132572975", "3038752371"},
+		},
+		{
+			method:  "C1",
+			valid:   []string{"0446786040", "0478046940", "0701625830", "0701625840", "0882095630", "5432112349", "5543223456", "5654334563", "5765445670", "5876556788"},
+			invalid: []string{"0446786240", "0478046340", "0701625730", "0701625440", "0882095130", "5432112341", "5543223458"},
+		},
+		{method: "C2", valid: []string{"2394871426", "4218461950", "7352569148", "5127485166", "8738142564"}, invalid: []string{"0328705283"}},
+		{method: "C3", valid: []string{"9294182", "4431276", "19919", "9000420530", "9000010006", "9000577650"}, invalid: []string{"17002", "123451", "122448", "9000734028", "9000733227"}},
+		{method: "C4", valid: []string{"0000000019", "0000292932", "0000094455", "9000420530", "9000010006", "9000577650"}, invalid: []string{"0000000017", "0000292933", "9000726558", "9001733457"}},
+		{
+			method: "C5",
+			valid: []string{
+				"0000301168", "0000302554", "0300020050", "0300566000", "1000061378", "1000061412", "4450164064", "4863476104", "5000000028", "5000000391",
+				"6450008149", "6800001016", "9000100012", "9000210017", "3060188103", "3070402023", "0030000000", "7000000000", "8500000000",
+			},
+			invalid: []string{
+				"0000302589", "0000507336", "0302555000", "0302589000", "1000061457", "1000061498", "4864446015", "4865038012", "5000001028", "5000001075",
+				"6450008150", "6542812818", "9000110012", "9000300310", "3081000783", "3081308871", "0111116430",
+			},
+		},
+		{
+			method: "C6",
+			valid: []string{
+				"0000065516", "0203178249", "1031405209", "1082012201", "2003455189", "2004001016", "3110150986", "3068459207", "5035105948", "5286102149",
+				"4012660028", "4100235626", "6028426119", "6861001755", "7008199027", "7002000023", "8526080015", "8711072264", "9000430223", "9000781153",
+			},
+			invalid: []string{
+				"0525111212", "0091423614", "1082311275", "1000118821", "2004306518", "2016001206", "3462816371", "3622548632", "4232300158", "4000456126",
+				"5002684526", "5564123850", "6295473774", "6640806317", "7000062022", "7006003027", "8654216984", "9000641509", "9000260986",
+			},
+		},
+		{method: "C7", valid: []string{"3500022", "38150900", "600103660", "39101181", "5050000", "94012341"}, invalid: []string{"94012342"}},
+		{method: "C8", valid: []string{"3456789019", "5678901231", "3456789012", "0022007130", "0123456789", "0552071285"}, invalid: []string{"3456789018"}},
+		{method: "C9", valid: []string{"3456789019", "5678901231", "0123456789"}, invalid: []string{"3456789012", "0022007130"}},
+		{method: "D0", valid: []string{"6100272324", "6100273479", "5700000000"}, invalid: []string{"6100272885", "6100273377", "6100274012"}},
+		{
+			method:  "D1",
+			valid:   []string{"0082012203", "1452683581", "2129642505", "3002000027", "4230001407", "5000065514", "6001526215", "7126502149", "9000430223"},
+			invalid: []string{"0000260986", "1062813622", "2256412314", "3012084101", "4006003027", "5814500990", "6128462594", "7000062035", "8003306026", "9000641509"},
+		},
+		{method: "D2", valid: []string{"189912137", "235308215", "4455667784", "1234567897", "51181008", "71214205"}, invalid: []string{"6414241", "179751314"}},
+		{method: "D3", valid: []string{"1600169591", "1600189151", "1800084079", "6019937007", "6021354007", "6030642006"}, invalid: []string{"6025017009", "6028267003", "6019835001"}},
+		{
+			method:  "D4",
+			valid:   []string{"1112048219", "2024601814", "3000005012", "4143406984", "5926485111", "6286304975", "7900256617", "8102228628", "9002364588"},
+			invalid: []string{"0359432843", "1000062023", "2204271250", "3051681017", "4000123456", "5212744564", "6286420010", "7859103459", "8003306026", "9916524534"},
+		},
+		{
+			method:  "D5",
+			valid:   []string{"5999718138", "1799222116", "0099632004", "0004711173", "0007093330", "0000127787", "0004711172", "0000100062", "0000393814", "0000950360"},
+			invalid: []string{"3299632008", "1999204293", "0399242139", "8623420004", "0001123458"},
+		},
+		{method: "D6", valid: []string{"3409", "585327", "1650513", "3601671056", "4402001046", "6100268241", "7001000681", "9000111105", "9001291005"}, invalid: []string{"3209", "7001000682"}},
+		{
+			method:  "D7",
+			valid:   []string{"0500018205", "0230103715", "0301000434", "0330035104", "0420001202", "0134637709", "0201005939", "0602006999"},
+			invalid: []string{"0501006102", "0231307867", "0301005331", "0330034104", "0420001302", "0135638809", "0202005939", "0601006977"},
+		},
+		{method: "D8", valid: []string{"1403414848", "6800000439", "6899999954", "0010000000", "0099999999"}, invalid: []string{"3700246864", "6800000438", "6899999955", "0009999999"}},
+		{method: "D9", valid: []string{"1234567897", "0123456782", "9876543210", "1234567890", "0123456789", "1100132044", "1100669030"}, invalid: []string{"1100789043", "1100914032"}},
+		{method: "E0", valid: []string{"1234568013", "1534568010", "2610015", "8741013011"}, invalid: []string{"1234769013", "2710014", "9741015011"}},
+		{method: "E1", valid: []string{"0134211909", "0100041104", "0100054106", "0200025107"}, invalid: []string{"0150013107", "0200035101", "0081313890", "4268550840", "0987402008"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		name := "method " + tt.method
+		if tt.bankCode != "" {
+			name += " at bank " + tt.bankCode
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			validate := func(account string) error { return ValidateAccount(tt.method, account) }
+			if tt.bankCode != "" {
+				dir := NewDirectory([]Bank{{BankCode: tt.bankCode, IsPaymentProvider: true, CheckMethod: tt.method}})
+				validate = func(account string) error { return dir.ValidateAccount(tt.bankCode, account) }
+			}
+
+			for _, account := range tt.valid {
+				require.NoError(t, validate(account), account)
+			}
+			for _, account := range tt.invalid {
+				require.ErrorIs(t, validate(account), ErrInvalidAccountNumber, account)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package blz
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// recordLength is the length of a single record of the Bundesbank bank code file, excluding the line break.
+const recordLength = 168
+
+var (
+	ErrInvalidRecord  = errors.New("invalid bank code file record")
+	ErrEmptyDirectory = errors.New("bank code file does not contain any records")
+)
+
+// Bank is a single record of the Bundesbank bank code (BLZ) file.
+// Ref: https://www.bundesbank.de/en/tasks/payment-systems/services/bank-sort-codes
+type Bank struct {
+	BankCode          string // Bankleitzahl
+	IsPaymentProvider bool   // Merkmal: true for the bank's main record, false for its branches
+	Name              string // Bezeichnung
+	PostalCode        string // PLZ
+	City              string // Ort
+	ShortName         string // Kurzbezeichnung
+	PAN               string // Institutsnummer für PAN
+	BIC               string
+	CheckMethod       string // Kennzeichen für Prüfzifferberechnungsmethode
+	RecordNumber      string // Datensatznummer
+	ChangeCode        string // Änderungskennzeichen: A(dded), D(eleted), U(nchanged), M(odified)
+	Deleted           bool   // Hinweis auf eine beabsichtigte Bankleitzahllöschung
+	SuccessorBankCode string // Nachfolge-Bankleitzahl
+}
+
+// Directory holds the banks of a Bundesbank bank code file, keyed by their bank code.
+type Directory struct {
+	banks map[string]Bank
+}
+
+// NewDirectory creates a Directory from the given banks.
+// If a bank code is listed more than once, the payment provider's main record wins.
+func NewDirectory(banks []Bank) *Directory {
+	d := &Directory{banks: make(map[string]Bank, len(banks))}
+	for _, bank := range banks {
+		if existing, ok := d.banks[bank.BankCode]; ok && (existing.IsPaymentProvider || !bank.IsPaymentProvider) {
+			continue
+		}
+		d.banks[bank.BankCode] = bank
+	}
+
+	return d
+}
+
+// Parse reads a Bundesbank bank code file in its fixed-width, ISO-8859-1 encoded text format.
+func Parse(r io.Reader) (*Directory, error) {
+	var banks []Bank
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		record := strings.TrimRight(scanner.Text(), "\r")
+		if record == "" {
+			continue
+		}
+
+		bank, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		banks = append(banks, bank)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read bank code file: %w", err)
+	}
+
+	if len(banks) == 0 {
+		return nil, ErrEmptyDirectory
+	}
+
+	return NewDirectory(banks), nil
+}
+
+// parseRecord parses a single, fixed-width record of the bank code file.
+// The Bundesbank publishes the file in ISO-8859-1, but UTF-8 encoded copies are accepted as well.
+func parseRecord(record string) (Bank, error) {
+	runes := []rune(record)
+	if !utf8.ValidString(record) {
+		runes = decodeLatin1(record)
+	}
+
+	if len(runes) != recordLength {
+		return Bank{}, fmt.Errorf("%w: expected %d characters, got %d", ErrInvalidRecord, recordLength, len(runes))
+	}
+
+	// field returns the record's field at the 1-based, inclusive position.
+	field := func(from, to int) string {
+		return strings.TrimSpace(string(runes[from-1 : to]))
+	}
+
+	bank := Bank{
+		BankCode:          field(1, 8),
+		IsPaymentProvider: field(9, 9) == "1",
+		Name:              field(10, 67),
+		PostalCode:        field(68, 72),
+		City:              field(73, 107),
+		ShortName:         field(108, 134),
+		PAN:               field(135, 139),
+		BIC:               field(140, 150),
+		CheckMethod:       field(151, 152),
+		RecordNumber:      field(153, 158),
+		ChangeCode:        field(159, 159),
+		Deleted:           field(160, 160) == "1",
+		SuccessorBankCode: field(161, 168),
+	}
+
+	if len(bank.BankCode) != 8 || strings.Trim(bank.BankCode, "0123456789") != "" {
+		return Bank{}, fmt.Errorf("%w: invalid bank code %q", ErrInvalidRecord, bank.BankCode)
+	}
+
+	if len(bank.CheckMethod) != 2 {
+		return Bank{}, fmt.Errorf("%w: invalid check method %q", ErrInvalidRecord, bank.CheckMethod)
+	}
+
+	if bank.SuccessorBankCode == "00000000" {
+		bank.SuccessorBankCode = ""
+	}
+
+	return bank, nil
+}
+
+// decodeLatin1 decodes an ISO-8859-1 encoded string, whose bytes map 1:1 to the first 256 unicode code points.
+func decodeLatin1(s string) []rune {
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+
+	return runes
+}
+
+// Lookup returns the bank with the given bank code.
+func (d *Directory) Lookup(bankCode string) (Bank, bool) {
+	bank, ok := d.banks[bankCode]
+	return bank, ok
+}
+
+// Banks returns all banks of the directory, one per bank code.
+func (d *Directory) Banks() []Bank {
+	banks := make([]Bank, 0, len(d.banks))
+	for _, bank := range d.banks {
+		banks = append(banks, bank)
+	}
+
+	return banks
+}
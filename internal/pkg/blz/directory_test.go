@@ -0,0 +1,108 @@
+package blz
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// record builds a fixed-width record of the Bundesbank bank code file.
+func record(bankCode, feature, name, city, bic, method, successor string) string {
+	return fmt.Sprintf(
+		"%-8s%-1s%-58s%-5s%-35s%-27s%-5s%-11s%-2s%-6s%-1s%-1s%-8s",
+		bankCode, feature, name, "50667", city, name, "", bic, method, "000001", "U", "0", successor,
+	)
+}
+
+func TestParse(t *testing.T) {
+	main := record("37040044", "1", "Commerzbank", "Köln", "COBADEFFXXX", "13", "00000000")
+	branch := record("37040044", "2", "Commerzbank Filiale", "Bonn", "", "13", "00000000")
+	postbank := record("10010010", "1", "Postbank Ndl der DB", "Berlin", "PBNKDEFFXXX", "24", "00000000")
+
+	tests := []struct {
+		name    string
+		file    string
+		want    map[string]Bank
+		wantErr error
+	}{
+		{
+			name: "success",
+			file: branch + "\r\n" + main + "\r\n\r\n" + postbank + "\n",
+			want: map[string]Bank{
+				"37040044": {
+					BankCode:          "37040044",
+					IsPaymentProvider: true,
+					Name:              "Commerzbank",
+					PostalCode:        "50667",
+					City:              "Köln",
+					ShortName:         "Commerzbank",
+					BIC:               "COBADEFFXXX",
+					CheckMethod:       "13",
+					RecordNumber:      "000001",
+					ChangeCode:        "U",
+				},
+				"10010010": {
+					BankCode:          "10010010",
+					IsPaymentProvider: true,
+					Name:              "Postbank Ndl der DB",
+					PostalCode:        "50667",
+					City:              "Berlin",
+					ShortName:         "Postbank Ndl der DB",
+					BIC:               "PBNKDEFFXXX",
+					CheckMethod:       "24",
+					RecordNumber:      "000001",
+					ChangeCode:        "U",
+				},
+			},
+		},
+		{
+			name:    "fails for empty file",
+			file:    "\n",
+			wantErr: ErrEmptyDirectory,
+		},
+		{
+			name:    "fails for short record",
+			file:    main[:100],
+			wantErr: ErrInvalidRecord,
+		},
+		{
+			name:    "fails for invalid bank code",
+			file:    record("3704004X", "1", "Commerzbank", "Köln", "COBADEFFXXX", "13", "00000000"),
+			wantErr: ErrInvalidRecord,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := Parse(strings.NewReader(tt.file))
+			require.ErrorIs(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			require.Len(t, got.Banks(), len(tt.want))
+			for bankCode, want := range tt.want {
+				bank, ok := got.Lookup(bankCode)
+				require.True(t, ok)
+				require.Equal(t, want, bank)
+			}
+		})
+	}
+}
+
+func TestParse_Latin1(t *testing.T) {
+	// "Köln" encoded in ISO-8859-1 is one byte shorter than in UTF-8
+	utf8Record := record("37040044", "1", "Commerzbank", "Köln", "COBADEFFXXX", "13", "00000000")
+	latin1Record := strings.Replace(utf8Record, "ö", "\xf6", 1)
+
+	dir, err := Parse(strings.NewReader(latin1Record))
+	require.NoError(t, err)
+
+	bank, ok := dir.Lookup("37040044")
+	require.True(t, ok)
+	require.Equal(t, "Köln", bank.City)
+}
@@ -0,0 +1,163 @@
+package epc
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SEPA schemes a bank can participate in, in the order they are reported.
+const (
+	SchemeSCT     = "SCT"      // SEPA Credit Transfer
+	SchemeSCTInst = "SCT_INST" // SEPA Instant Credit Transfer
+	SchemeSDDCore = "SDD_CORE" // SEPA Direct Debit Core
+	SchemeSDDB2B  = "SDD_B2B"  // SEPA Direct Debit Business to Business
+)
+
+var schemes = []string{SchemeSCT, SchemeSCTInst, SchemeSDDCore, SchemeSDDB2B}
+
+var ErrInvalidCSV = errors.New("invalid EPC register csv")
+
+// Participant is the participation of a bank, identified by its BIC, in one of the SEPA schemes.
+type Participant struct {
+	BIC    string
+	Name   string
+	Scheme string
+}
+
+// Register maps the BICs of banks to the SEPA schemes they participate in.
+type Register struct {
+	schemes map[string]map[string]bool
+}
+
+// NewRegister creates a Register of the participants. 8-character BICs are the same as their 11-character form
+// with the branch code "XXX".
+func NewRegister(participants []Participant) *Register {
+	r := &Register{schemes: make(map[string]map[string]bool, len(participants))}
+	for _, p := range participants {
+		bic := normalizeBIC(p.BIC)
+		if r.schemes[bic] == nil {
+			r.schemes[bic] = map[string]bool{}
+		}
+		r.schemes[bic][p.Scheme] = true
+	}
+
+	return r
+}
+
+// Lookup returns the schemes the bank with the BIC is reachable by. If the register does not list the branch,
+// the schemes of the institution's primary office are returned, as it receives the payments of its branches.
+func (r *Register) Lookup(bic string) ([]string, bool) {
+	bic = normalizeBIC(bic)
+	participations, ok := r.schemes[bic]
+	if !ok && len(bic) == 11 {
+		participations, ok = r.schemes[bic[:8]+"XXX"]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	var reachable []string
+	for _, scheme := range schemes {
+		if participations[scheme] {
+			reachable = append(reachable, scheme)
+		}
+	}
+
+	return reachable, true
+}
+
+// Len returns the number of BICs in the register.
+func (r *Register) Len() int {
+	return len(r.schemes)
+}
+
+// normalizeBIC returns the 11-character form of 8-character BICs.
+func normalizeBIC(bic string) string {
+	bic = strings.ToUpper(bic)
+	if len(bic) == 8 {
+		return bic + "XXX"
+	}
+
+	return bic
+}
+
+// ParseCSV reads the participants from a comma separated export of the EPC register of participants with a header row,
+// listing a bank once per scheme it participates in, e.g.
+//
+//	bic,name,scheme
+//	COBADEFFXXX,Commerzbank,SCT
+//	COBADEFFXXX,Commerzbank,SCT Inst
+//
+// The columns "bic" and "scheme" are required. Schemes are SCT, SCT Inst, SDD Core and SDD B2B, written with
+// spaces, dashes or underscores.
+func ParseCSV(r io.Reader) ([]Participant, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %s", ErrInvalidCSV, err)
+	}
+
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	bicIndex, ok := indexes["bic"]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSV, "bic")
+	}
+	schemeIndex, ok := indexes["scheme"]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSV, "scheme")
+	}
+	nameIndex, hasName := indexes["name"]
+
+	var participants []Participant
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCSV, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		p := Participant{
+			BIC:    strings.ToUpper(strings.TrimSpace(record[bicIndex])),
+			Scheme: parseScheme(record[schemeIndex]),
+		}
+		if hasName {
+			p.Name = strings.TrimSpace(record[nameIndex])
+		}
+
+		if len(p.BIC) != 8 && len(p.BIC) != 11 {
+			return nil, fmt.Errorf("%w: line %d: invalid BIC %q", ErrInvalidCSV, line, p.BIC)
+		}
+		if p.Scheme == "" {
+			return nil, fmt.Errorf("%w: line %d: unknown scheme %q", ErrInvalidCSV, line, record[schemeIndex])
+		}
+
+		participants = append(participants, p)
+	}
+
+	return participants, nil
+}
+
+// parseScheme returns the scheme written as s, or an empty string if s is no known scheme.
+func parseScheme(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+	for _, scheme := range schemes {
+		if s == scheme {
+			return scheme
+		}
+	}
+
+	return ""
+}
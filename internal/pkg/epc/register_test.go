@@ -0,0 +1,106 @@
+package epc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister_Lookup(t *testing.T) {
+	register := NewRegister([]Participant{
+		{BIC: "COBADEFFXXX", Scheme: SchemeSDDCore},
+		{BIC: "COBADEFF", Scheme: SchemeSCT},
+		{BIC: "COBADEFFXXX", Scheme: SchemeSCTInst},
+		{BIC: "NWBKGB2L", Scheme: SchemeSCT},
+		{BIC: "NWBKGB2L123", Scheme: SchemeSDDB2B},
+	})
+
+	tests := []struct {
+		name   string
+		bic    string
+		want   []string
+		wantOk bool
+	}{
+		{name: "schemes in fixed order", bic: "COBADEFFXXX", want: []string{SchemeSCT, SchemeSCTInst, SchemeSDDCore}, wantOk: true},
+		{name: "8-character BIC", bic: "COBADEFF", want: []string{SchemeSCT, SchemeSCTInst, SchemeSDDCore}, wantOk: true},
+		{name: "branch", bic: "NWBKGB2L123", want: []string{SchemeSDDB2B}, wantOk: true},
+		{name: "unlisted branch falls back to primary office", bic: "COBADEFF500", want: []string{SchemeSCT, SchemeSCTInst, SchemeSDDCore}, wantOk: true},
+		{name: "lowercase", bic: "nwbkgb2l", want: []string{SchemeSCT}, wantOk: true},
+		{name: "unknown bank", bic: "DEUTDEFF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, ok := register.Lookup(tt.bic)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []Participant
+		wantErr error
+	}{
+		{
+			name: "success",
+			file: "bic,name,scheme\n" +
+				"cobadeffxxx,Commerzbank,SCT\n" +
+				"COBADEFFXXX,Commerzbank,SCT Inst\n" +
+				"COBADEFF, Commerzbank, sdd-core\n" +
+				"COBADEFF,Commerzbank,SDD_B2B\n",
+			want: []Participant{
+				{BIC: "COBADEFFXXX", Name: "Commerzbank", Scheme: SchemeSCT},
+				{BIC: "COBADEFFXXX", Name: "Commerzbank", Scheme: SchemeSCTInst},
+				{BIC: "COBADEFF", Name: "Commerzbank", Scheme: SchemeSDDCore},
+				{BIC: "COBADEFF", Name: "Commerzbank", Scheme: SchemeSDDB2B},
+			},
+		},
+		{
+			name: "columns in any order, name left out",
+			file: "Scheme,BIC\nSCT,NWBKGB2L\n",
+			want: []Participant{{BIC: "NWBKGB2L", Scheme: SchemeSCT}},
+		},
+		{
+			name:    "fails for missing bic column",
+			file:    "name,scheme\nCommerzbank,SCT\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for missing scheme column",
+			file:    "bic,name\nCOBADEFF,Commerzbank\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for unknown scheme",
+			file:    "bic,scheme\nCOBADEFF,SEPA Request to Pay\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for invalid BIC",
+			file:    "bic,scheme\nCOBADE,SCT\n",
+			wantErr: ErrInvalidCSV,
+		},
+		{
+			name:    "fails for empty file",
+			file:    "",
+			wantErr: ErrInvalidCSV,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := ParseCSV(strings.NewReader(tt.file))
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
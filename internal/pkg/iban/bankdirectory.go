@@ -0,0 +1,63 @@
+package iban
+
+import (
+	"fmt"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+)
+
+// WithBankDirectory derives the bank, including its BIC and address, from the bank and branch code of the BBAN.
+func WithBankDirectory(dir *bankdir.Directory) Option {
+	return func(svc *Service) error {
+		svc.bankDirectory = dir
+		return nil
+	}
+}
+
+// lookupBank returns the bank identified by the iban's bank and branch code, or nil if it is unknown.
+// Territories share the bank directory of their governing country.
+func (svc *Service) lookupBank(iban IBAN) *Bank {
+	if svc.bankDirectory == nil || iban.BankCode == "" {
+		return nil
+	}
+
+	bank, ok := svc.bankDirectory.Lookup(iban.CountryCode, iban.BankCode, iban.BranchCode)
+	if !ok && iban.GoverningCountryCode != "" {
+		bank, ok = svc.bankDirectory.Lookup(iban.GoverningCountryCode, iban.BankCode, iban.BranchCode)
+	}
+	if !ok {
+		return nil
+	}
+
+	return &Bank{
+		Name:       bank.Name,
+		BIC:        bank.BIC,
+		Street:     bank.Street,
+		PostalCode: bank.PostalCode,
+		City:       bank.City,
+	}
+}
+
+// lookupBankCode returns the code of the bank the branch code belongs to, or false if the bank directory does not
+// identify it. Territories share the bank directory of their governing country.
+func (svc *Service) lookupBankCode(c countryValidator, branchCode string) (string, bool) {
+	if svc.bankDirectory == nil {
+		return "", false
+	}
+
+	bankCode, ok := svc.bankDirectory.BankCode(c.CountryCode, branchCode)
+	if !ok && c.GoverningCountryCode != "" {
+		bankCode, ok = svc.bankDirectory.BankCode(c.GoverningCountryCode, branchCode)
+	}
+
+	return bankCode, ok
+}
+
+// validateBank returns ErrUnknownBankCode if the bank of the annotated iban is not in the bank directory.
+func (svc *Service) validateBank(iban IBAN) error {
+	if svc.lookupBank(iban) == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownBankCode, iban.BankCode)
+	}
+
+	return nil
+}
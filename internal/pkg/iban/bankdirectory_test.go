@@ -0,0 +1,124 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+)
+
+func TestService_Parse_WithBankDirectory(t *testing.T) {
+	dir := bankdir.NewDirectory([]bankdir.Bank{
+		{CountryCode: "GB", BankCode: "NWBK", Name: "National Westminster Bank", BIC: "NWBKGB2L"},
+		{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", Name: "National Westminster Bank", BIC: "NWBKGB2L", City: "London"},
+		{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank", BIC: "COBADEFFXXX", PostalCode: "50447", City: "Köln"},
+	})
+
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    *Bank
+	}{
+		{
+			name:    "bank without branch code",
+			ibanStr: "DE89370400440532013000",
+			want:    &Bank{Name: "Commerzbank", BIC: "COBADEFFXXX", PostalCode: "50447", City: "Köln"},
+		},
+		{
+			name:    "branch",
+			ibanStr: "GB29NWBK60161331926819",
+			want:    &Bank{Name: "National Westminster Bank", BIC: "NWBKGB2L", City: "London"},
+		},
+		{
+			name:    "territory uses bank directory of governing country",
+			ibanStr: "JE90NWBK60161331926819",
+			want:    &Bank{Name: "National Westminster Bank", BIC: "NWBKGB2L", City: "London"},
+		},
+		{
+			name:    "unknown bank",
+			ibanStr: "GB33BUKB20201555555555",
+		},
+		{
+			name:    "BBAN without bank code",
+			ibanStr: "GB29NWBK6016133192681",
+		},
+	}
+
+	svc, err := NewService(WithBankDirectory(dir))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, iban.Bank)
+		})
+	}
+}
+
+func TestService_Generate_WithBankDirectory(t *testing.T) {
+	dir := bankdir.NewDirectory([]bankdir.Bank{
+		{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", Name: "National Westminster Bank", BIC: "NWBKGB2L"},
+		{CountryCode: "GB", BankCode: "BARC", BranchCode: "200000", Name: "Barclays Bank"},
+		{CountryCode: "GB", BankCode: "BUKB", BranchCode: "200000", Name: "Barclays Bank UK"},
+	})
+
+	tests := []struct {
+		name    string
+		details NationalDetails
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "bank code is derived from UK sort code",
+			details: NationalDetails{CountryCode: "GB", BranchCode: "601613", AccountNumber: "31926819"},
+			want:    "GB29NWBK60161331926819",
+		},
+		{
+			name:    "territory uses bank directory of governing country",
+			details: NationalDetails{CountryCode: "JE", BranchCode: "601613", AccountNumber: "31926819"},
+			want:    "JE90NWBK60161331926819",
+		},
+		{
+			name:    "given bank code is kept",
+			details: NationalDetails{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", AccountNumber: "31926819"},
+			want:    "GB29NWBK60161331926819",
+		},
+		{
+			name:    "fails for unknown sort code",
+			details: NationalDetails{CountryCode: "GB", BranchCode: "123456", AccountNumber: "31926819"},
+			wantErr: ErrBankCodeNotDerivable,
+		},
+		{
+			name:    "fails for sort code of several banks",
+			details: NationalDetails{CountryCode: "GB", BranchCode: "200000", AccountNumber: "31926819"},
+			wantErr: ErrBankCodeNotDerivable,
+		},
+		{
+			name:    "fails for missing bank code of country without branch code",
+			details: NationalDetails{CountryCode: "DE", AccountNumber: "532013000"},
+			wantErr: ErrMissingComponent,
+		},
+	}
+
+	svc, err := NewService(WithBankDirectory(dir))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := svc.Generate(tt.details)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
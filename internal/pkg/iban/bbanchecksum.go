@@ -0,0 +1,362 @@
+package iban
+
+import (
+	"strings"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/mod97"
+)
+
+// bbanChecksum is a national algorithm that validates the check digits within a BBAN.
+type bbanChecksum struct {
+	Name string
+	Func func(bban string) bool
+}
+
+var (
+	ribKey         = bbanChecksum{Name: "RIB key", Func: validateRIBKey}
+	cin            = bbanChecksum{Name: "CIN", Func: validateCIN}
+	iso7064Mod97   = bbanChecksum{Name: "ISO 7064 MOD 97-10", Func: validateISO7064Mod97}
+	czechSlovakMod = bbanChecksum{Name: "Czech/Slovak MOD 11", Func: validateCzechSlovakMod11}
+
+	// bbanChecksums holds the national BBAN checksum algorithms, which are not part of the SWIFT IBAN Registry.
+	// It covers the experimental countries as well. NL is left out on purpose: Dutch banks no longer issue
+	// account numbers that satisfy the "elfproef" (MOD 11), so it would reject valid IBANs.
+	bbanChecksums = map[string]bbanChecksum{
+		"AL": {Name: "Albanian 9-7-3-1 weighted", Func: validateAlbanian9731},
+		"BA": iso7064Mod97,
+		"BE": {Name: "Belgian MOD 97", Func: validateBelgianMod97},
+		"BF": ribKey,
+		"BI": ribKey,
+		"BJ": ribKey,
+		"CF": ribKey,
+		"CG": ribKey,
+		"CI": ribKey,
+		"CM": ribKey,
+		"CZ": czechSlovakMod,
+		"DJ": ribKey,
+		"EE": {Name: "Estonian 7-3-1 weighted", Func: validateEstonian731},
+		"ES": {Name: "Spanish DC", Func: validateSpanishDC},
+		"FI": {Name: "Finnish Luhn", Func: validateFinnishLuhn},
+		"FR": ribKey,
+		"GA": ribKey,
+		"GQ": ribKey,
+		"HR": {Name: "Croatian ISO 7064 MOD 11,10", Func: validateCroatianMod1110},
+		"HU": {Name: "Hungarian 9-7-3-1 weighted", Func: validateHungarian9731},
+		"IS": {Name: "Icelandic kennitala MOD 11", Func: validateIcelandicKennitala},
+		"IT": cin,
+		"KM": ribKey,
+		"MA": ribKey,
+		"MC": ribKey,
+		"ME": iso7064Mod97,
+		"MG": ribKey,
+		"MK": iso7064Mod97,
+		"ML": ribKey,
+		"MR": ribKey,
+		"NE": ribKey,
+		"NO": {Name: "Norwegian MOD 11", Func: validateNorwegianMod11},
+		"PL": {Name: "Polish 3-9-7-1 weighted", Func: validatePolish3971},
+		"PT": iso7064Mod97,
+		"RS": iso7064Mod97,
+		"SI": iso7064Mod97,
+		"SK": czechSlovakMod,
+		"SM": cin,
+		"SN": ribKey,
+		"TD": ribKey,
+		"TG": ribKey,
+		"TL": iso7064Mod97,
+		"TN": ribKey,
+		"XK": iso7064Mod97,
+	}
+)
+
+// weightedSum multiplies every digit of s with the weight at the same position and sums up the products.
+// It returns false if s contains anything other than digits or if there are fewer weights than digits.
+func weightedSum(s string, weights []int) (int, bool) {
+	if len(s) > len(weights) {
+		return 0, false
+	}
+
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+		sum += int(s[i]-'0') * weights[i]
+	}
+
+	return sum, true
+}
+
+// isDigits reports whether s is non-empty and consists of digits only.
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// validateRIBKey validates the two trailing key digits of a French-style RIB (relevé d'identité bancaire), which is
+// used by France, Monaco, several former French territories and the CFA franc zone. The key is chosen such that
+// the whole RIB, with letters replaced by their RIB digit, is divisible by 97.
+func validateRIBKey(bban string) bool {
+	var sb strings.Builder
+	for _, r := range bban {
+		switch {
+		case r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case r >= 'A' && r <= 'I':
+			sb.WriteRune('1' + r - 'A')
+		case r >= 'J' && r <= 'R':
+			sb.WriteRune('1' + r - 'J')
+		case r >= 'S' && r <= 'Z':
+			sb.WriteRune('2' + r - 'S')
+		default:
+			return false
+		}
+	}
+
+	return len(bban) > 2 && mod97.Remainder(sb.String()) == 0
+}
+
+// validateCIN validates the leading control letter (CIN, carattere interno di controllo) of an Italian or Sammarinese
+// BBAN, which is computed over the bank code, branch code and account number.
+func validateCIN(bban string) bool {
+	// values of digits and letters at odd positions; even positions use A/0 = 0, B/1 = 1, ..., Z = 25
+	oddValues := []int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21, 2, 4, 18, 20, 11, 3, 6, 8, 12, 14, 16, 10, 22, 25, 24, 23}
+
+	if len(bban) != 23 || bban[0] < 'A' || bban[0] > 'Z' {
+		return false
+	}
+
+	sum := 0
+	for i := 1; i < len(bban); i++ {
+		var value int
+		switch c := bban[i]; {
+		case c >= '0' && c <= '9':
+			value = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			value = int(c - 'A')
+		default:
+			return false
+		}
+
+		if i%2 == 1 { // odd position, counted from 1 after the CIN
+			value = oddValues[value]
+		}
+		sum += value
+	}
+
+	return bban[0] == byte('A'+sum%26)
+}
+
+// validateISO7064Mod97 validates BBANs whose two trailing check digits are computed with ISO 7064 MOD 97-10 over
+// the whole BBAN, the same way as the IBAN check digits.
+func validateISO7064Mod97(bban string) bool {
+	numeric, err := mod97.ToNumeric(bban)
+	if err != nil || len(numeric) < 3 {
+		return false
+	}
+
+	return mod97.Remainder(numeric) == 1
+}
+
+// validateBelgianMod97 validates the last two digits of a Belgian BBAN,
+// which are the first ten digits modulo 97 (or 97 if that is 0).
+func validateBelgianMod97(bban string) bool {
+	if len(bban) != 12 || !isDigits(bban) {
+		return false
+	}
+
+	remainder := mod97.Remainder(bban[:10])
+	if remainder == 0 {
+		remainder = 97
+	}
+
+	return bban[10:] == twoDigits(remainder)
+}
+
+// validateSpanishDC validates both Spanish control digits (dígitos de control): the first covers bank and branch code,
+// the second covers the account number.
+func validateSpanishDC(bban string) bool {
+	weights := []int{1, 2, 4, 8, 5, 10, 9, 7, 3, 6}
+	controlDigit := func(s string) (byte, bool) {
+		sum, ok := weightedSum(s, weights)
+		if !ok {
+			return 0, false
+		}
+
+		digit := 11 - sum%11
+		switch digit {
+		case 11:
+			digit = 0
+		case 10:
+			digit = 1
+		}
+
+		return byte('0' + digit), true
+	}
+
+	if len(bban) != 20 {
+		return false
+	}
+
+	first, ok := controlDigit("00" + bban[:8])
+	if !ok || first != bban[8] {
+		return false
+	}
+
+	second, ok := controlDigit(bban[10:])
+	return ok && second == bban[9]
+}
+
+// validateNorwegianMod11 validates the last digit of a Norwegian BBAN using MOD 11 with the weights 5,4,3,2,7,6,5,4,3,2.
+// Account numbers whose check digit would be 10 are never issued.
+func validateNorwegianMod11(bban string) bool {
+	if len(bban) != 11 {
+		return false
+	}
+
+	sum, ok := weightedSum(bban[:10], []int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2})
+	if !ok || !isDigits(bban[10:]) {
+		return false
+	}
+
+	digit := (11 - sum%11) % 11
+	return digit != 10 && bban[10] == byte('0'+digit)
+}
+
+// validateEstonian731 validates the last digit of an Estonian BBAN, computed over the branch code and account number
+// with the repeating weights 7,3,1 from right to left.
+func validateEstonian731(bban string) bool {
+	if len(bban) != 16 || !isDigits(bban) {
+		return false
+	}
+
+	sum := 0
+	weights := []int{7, 3, 1}
+	for i, j := 14, 0; i >= 2; i, j = i-1, j+1 {
+		sum += int(bban[i]-'0') * weights[j%3]
+	}
+
+	return bban[15] == byte('0'+(10-sum%10)%10)
+}
+
+// validateFinnishLuhn validates the last digit of a Finnish BBAN using the Luhn algorithm.
+func validateFinnishLuhn(bban string) bool {
+	if len(bban) != 14 || !isDigits(bban) {
+		return false
+	}
+
+	sum := 0
+	for i := len(bban) - 1; i >= 0; i-- {
+		digit := int(bban[i] - '0')
+		if (len(bban)-1-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// validateCroatianMod1110 validates the check digits of the Croatian bank code (7th digit)
+// and account number (17th digit) using ISO 7064 MOD 11,10.
+func validateCroatianMod1110(bban string) bool {
+	checkDigit := func(s string) byte {
+		product := 10
+		for i := 0; i < len(s); i++ {
+			sum := (int(s[i]-'0') + product) % 10
+			if sum == 0 {
+				sum = 10
+			}
+			product = (sum * 2) % 11
+		}
+
+		return byte('0' + (11-product)%10)
+	}
+
+	if len(bban) != 17 || !isDigits(bban) {
+		return false
+	}
+
+	return checkDigit(bban[:6]) == bban[6] && checkDigit(bban[7:16]) == bban[16]
+}
+
+// validateHungarian9731 validates both Hungarian check digits: the 8th digit covers bank and branch code,
+// the 24th digit covers the account number. Both use the repeating weights 9,7,3,1.
+func validateHungarian9731(bban string) bool {
+	weights := []int{9, 7, 3, 1, 9, 7, 3, 1, 9, 7, 3, 1, 9, 7, 3, 1}
+
+	if len(bban) != 24 {
+		return false
+	}
+
+	first, ok := weightedSum(bban[:8], weights)
+	if !ok || first%10 != 0 {
+		return false
+	}
+
+	second, ok := weightedSum(bban[8:], weights)
+	return ok && second%10 == 0
+}
+
+// validatePolish3971 validates the 8th digit of a Polish BBAN, the check digit of the bank's sort code,
+// using the weights 3,9,7,1,3,9,7.
+func validatePolish3971(bban string) bool {
+	if len(bban) != 24 || !isDigits(bban[7:8]) {
+		return false
+	}
+
+	sum, ok := weightedSum(bban[:7], []int{3, 9, 7, 1, 3, 9, 7})
+	return ok && bban[7] == byte('0'+(10-sum%10)%10)
+}
+
+// validateCzechSlovakMod11 validates the account number prefix and the account number of a Czech or Slovak BBAN,
+// which must both be divisible by 11 when weighted with 6,3,7,9,10,5,8,4,2,1.
+func validateCzechSlovakMod11(bban string) bool {
+	weights := []int{6, 3, 7, 9, 10, 5, 8, 4, 2, 1}
+
+	if len(bban) != 20 {
+		return false
+	}
+
+	prefix, ok := weightedSum(bban[4:10], weights[4:])
+	if !ok || prefix%11 != 0 {
+		return false
+	}
+
+	number, ok := weightedSum(bban[10:], weights)
+	return ok && number%11 == 0
+}
+
+// validateAlbanian9731 validates the 8th digit of an Albanian BBAN, the check digit of bank and branch code,
+// using the weights 9,7,3,1,9,7,3.
+func validateAlbanian9731(bban string) bool {
+	if len(bban) < 8 || !isDigits(bban[7:8]) {
+		return false
+	}
+
+	sum, ok := weightedSum(bban[:7], []int{9, 7, 3, 1, 9, 7, 3})
+	return ok && bban[7] == byte('0'+(10-sum%10)%10)
+}
+
+// validateIcelandicKennitala validates the check digit (9th digit) of the account holder's national identification
+// number (kennitala), which makes up the last ten digits of an Icelandic BBAN.
+func validateIcelandicKennitala(bban string) bool {
+	if len(bban) != 22 || !isDigits(bban[20:21]) {
+		return false
+	}
+
+	sum, ok := weightedSum(bban[12:20], []int{3, 2, 7, 6, 5, 4, 3, 2})
+	if !ok {
+		return false
+	}
+
+	digit := (11 - sum%11) % 11
+	return digit != 10 && bban[20] == byte('0'+digit)
+}
+
+// twoDigits formats n as a zero-padded two-digit string.
+func twoDigits(n uint) string {
+	return string([]byte{byte('0' + n/10%10), byte('0' + n%10)})
+}
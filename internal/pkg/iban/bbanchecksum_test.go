@@ -0,0 +1,97 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bbanChecksums(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		bban        string
+		want        bool
+	}{
+		{name: "AL valid", countryCode: "AL", bban: "212110090000000235698741", want: true},
+		{name: "AL invalid", countryCode: "AL", bban: "212110080000000235698741"},
+		{name: "BA valid", countryCode: "BA", bban: "1290079401028494", want: true},
+		{name: "BA invalid", countryCode: "BA", bban: "1290079401028495"},
+		{name: "BE valid", countryCode: "BE", bban: "539007547034", want: true},
+		{name: "BE invalid", countryCode: "BE", bban: "539007547035"},
+		{name: "BE remainder 0 is 97", countryCode: "BE", bban: "000000009797", want: true},
+		{name: "CZ valid", countryCode: "CZ", bban: "08000000192000145399", want: true},
+		{name: "CZ invalid prefix", countryCode: "CZ", bban: "08000000202000145399"},
+		{name: "CZ invalid account number", countryCode: "CZ", bban: "08000000192000145398"},
+		{name: "EE valid", countryCode: "EE", bban: "2200221020145685", want: true},
+		{name: "EE invalid", countryCode: "EE", bban: "2200221020145684"},
+		{name: "ES valid", countryCode: "ES", bban: "21000418450200051332", want: true},
+		{name: "ES invalid first control digit", countryCode: "ES", bban: "21000418350200051332"},
+		{name: "ES invalid second control digit", countryCode: "ES", bban: "21000418460200051332"},
+		{name: "FI valid", countryCode: "FI", bban: "12345600000785", want: true},
+		{name: "FI invalid", countryCode: "FI", bban: "12345600000786"},
+		{name: "FR valid", countryCode: "FR", bban: "20041010050500013M02606", want: true},
+		{name: "FR invalid", countryCode: "FR", bban: "20041010050500013N02606"},
+		{name: "FR lowercase letter", countryCode: "FR", bban: "20041010050500013m02606"},
+		{name: "HR valid", countryCode: "HR", bban: "10010051863000160", want: true},
+		{name: "HR invalid bank code", countryCode: "HR", bban: "10010061863000160"},
+		{name: "HR invalid account number", countryCode: "HR", bban: "10010051863000161"},
+		{name: "HU valid", countryCode: "HU", bban: "117730161111101800000000", want: true},
+		{name: "HU invalid", countryCode: "HU", bban: "117730171111101800000000"},
+		{name: "IS valid", countryCode: "IS", bban: "0159260076545510730339", want: true},
+		{name: "IS invalid", countryCode: "IS", bban: "0159260076545510730349"},
+		{name: "IT valid", countryCode: "IT", bban: "X0542811101000000123456", want: true},
+		{name: "IT invalid", countryCode: "IT", bban: "Y0542811101000000123456"},
+		{name: "MC valid", countryCode: "MC", bban: "11222000010123456789030", want: true},
+		{name: "ME valid", countryCode: "ME", bban: "505000012345678951", want: true},
+		{name: "ME invalid", countryCode: "ME", bban: "505000012345678952"},
+		{name: "MR valid", countryCode: "MR", bban: "00020001010000123456753", want: true},
+		{name: "NO valid", countryCode: "NO", bban: "86011117947", want: true},
+		{name: "NO invalid", countryCode: "NO", bban: "86011117948"},
+		{name: "PL valid", countryCode: "PL", bban: "109010140000071219812874", want: true},
+		{name: "PL invalid", countryCode: "PL", bban: "109010150000071219812874"},
+		{name: "PT valid", countryCode: "PT", bban: "000201231234567890154", want: true},
+		{name: "PT invalid", countryCode: "PT", bban: "000201231234567890155"},
+		{name: "RS valid", countryCode: "RS", bban: "260005601001611379", want: true},
+		{name: "SI valid", countryCode: "SI", bban: "263300012039086", want: true},
+		{name: "SI invalid", countryCode: "SI", bban: "263300012039087"},
+		{name: "SK valid", countryCode: "SK", bban: "12000000198742637541", want: true},
+		{name: "SM valid", countryCode: "SM", bban: "U0322509800000000270100", want: true},
+		{name: "TN valid", countryCode: "TN", bban: "10006035183598478831", want: true},
+		{name: "TN invalid", countryCode: "TN", bban: "10006035183598478832"},
+		{name: "XK valid", countryCode: "XK", bban: "1212012345678906", want: true},
+		{name: "wrong length is invalid", countryCode: "ES", bban: "2100041845020005133"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			checksum, ok := bbanChecksums[tt.countryCode]
+			require.True(t, ok)
+			require.Equal(t, tt.want, checksum.Func(tt.bban))
+		})
+	}
+}
+
+func Test_weightedSum(t *testing.T) {
+	sum, ok := weightedSum("123", []int{1, 2, 3})
+	require.True(t, ok)
+	require.Equal(t, 14, sum)
+
+	_, ok = weightedSum("12A", []int{1, 2, 3})
+	require.False(t, ok)
+
+	_, ok = weightedSum("1234", []int{1, 2, 3})
+	require.False(t, ok)
+}
+
+func TestService_Validate_DutchAccountsWithoutElfproef(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	// the account number does not satisfy the "elfproef", like many accounts of newer Dutch banks
+	iban, err := svc.Parse("NL25BUNQ2098765432")
+	require.NoError(t, err)
+	require.NoError(t, svc.Validate(iban))
+}
@@ -0,0 +1,209 @@
+package iban
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Character classes of the SWIFT IBAN Registry's structure notation.
+const (
+	classNumeric      = 'n' // digits 0-9
+	classAlpha        = 'a' // uppercase letters A-Z
+	classAlphanumeric = 'c' // uppercase letters and digits
+	classSpace        = 'e' // blank space
+)
+
+// bbanElement is a single element of a BBAN structure, e.g. "8!n" (exactly eight digits) or "3a" (up to three letters).
+type bbanElement struct {
+	Length int
+	Fixed  bool
+	Class  byte
+}
+
+// bbanStructure is a compiled BBAN structure as described by the SWIFT IBAN Registry notation, e.g. "4!a6!n8!n".
+type bbanStructure struct {
+	notation string
+	elements []bbanElement
+}
+
+// parseBBANStructure compiles the registry's BBAN structure notation (e.g. "4!a6!n8!n") into a bbanStructure.
+func parseBBANStructure(notation string) (bbanStructure, error) {
+	if notation == "" {
+		return bbanStructure{}, fmt.Errorf("bban structure is empty")
+	}
+
+	var elements []bbanElement
+	for i := 0; i < len(notation); {
+		start := i
+		for i < len(notation) && notation[i] >= '0' && notation[i] <= '9' {
+			i++
+		}
+
+		length, err := strconv.Atoi(notation[start:i])
+		if err != nil || length == 0 {
+			return bbanStructure{}, fmt.Errorf("invalid element length at position %d of bban structure %q", start, notation)
+		}
+
+		fixed := i < len(notation) && notation[i] == '!'
+		if fixed {
+			i++
+		}
+
+		if i >= len(notation) {
+			return bbanStructure{}, fmt.Errorf("missing character class at the end of bban structure %q", notation)
+		}
+
+		switch class := notation[i]; class {
+		case classNumeric, classAlpha, classAlphanumeric, classSpace:
+			elements = append(elements, bbanElement{Length: length, Fixed: fixed, Class: class})
+		default:
+			return bbanStructure{}, fmt.Errorf("unknown character class %q in bban structure %q", class, notation)
+		}
+		i++
+	}
+
+	return bbanStructure{notation: notation, elements: elements}, nil
+}
+
+// mustParseBBANStructure is like parseBBANStructure but panics if the notation cannot be parsed.
+func mustParseBBANStructure(notation string) bbanStructure {
+	s, err := parseBBANStructure(notation)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// String returns the structure in registry notation.
+func (s bbanStructure) String() string {
+	return s.notation
+}
+
+// MaxLength returns the maximum length of a BBAN matching the structure.
+func (s bbanStructure) MaxLength() int {
+	length := 0
+	for _, e := range s.elements {
+		length += e.Length
+	}
+
+	return length
+}
+
+// Match reports whether the bban satisfies the structure.
+func (s bbanStructure) Match(bban string) bool {
+	return matchElements(s.elements, bban)
+}
+
+// matchElements matches the bban against the elements. Fixed-length elements are checked in a single pass,
+// variable-length elements backtrack over all lengths they allow.
+func matchElements(elements []bbanElement, bban string) bool {
+	for i, e := range elements {
+		if e.Fixed {
+			if len(bban) < e.Length || !matchClass(e.Class, bban[:e.Length]) {
+				return false
+			}
+			bban = bban[e.Length:]
+			continue
+		}
+
+		for n := e.Length; n > 0; n-- {
+			if len(bban) >= n && matchClass(e.Class, bban[:n]) && matchElements(elements[i+1:], bban[n:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return bban == ""
+}
+
+// matchClass reports whether every character of s belongs to the character class.
+func matchClass(class byte, s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		isDigit := c >= '0' && c <= '9'
+		isUpper := c >= 'A' && c <= 'Z'
+
+		switch {
+		case class == classNumeric && isDigit,
+			class == classAlpha && isUpper,
+			class == classAlphanumeric && (isDigit || isUpper),
+			class == classSpace && c == ' ':
+			continue
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// Describe returns a human-readable description of the structure, e.g. "4 letters, 14 digits".
+func (s bbanStructure) Describe() string {
+	var merged []bbanElement
+	for _, e := range s.elements {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].Fixed && e.Fixed && merged[last].Class == e.Class {
+			merged[last].Length += e.Length
+			continue
+		}
+		merged = append(merged, e)
+	}
+
+	parts := make([]string, 0, len(merged))
+	for _, e := range merged {
+		part := fmt.Sprintf("%d %s", e.Length, className(e.Class, e.Length != 1))
+		if !e.Fixed {
+			part = "up to " + part
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// className returns the human-readable name of a character class.
+func className(class byte, plural bool) string {
+	names := map[byte][2]string{
+		classNumeric:      {"digit", "digits"},
+		classAlpha:        {"letter", "letters"},
+		classAlphanumeric: {"alphanumeric character", "alphanumeric characters"},
+		classSpace:        {"space", "spaces"},
+	}
+
+	if plural {
+		return names[class][1]
+	}
+
+	return names[class][0]
+}
+
+// FirstMismatch returns the position of the first character of the bban that does not belong to the character class
+// the structure expects at its position, or -1 if there is none or the position is ambiguous,
+// i.e. follows a variable-length element.
+func (s bbanStructure) FirstMismatch(bban string) int {
+	pos := 0
+	for _, e := range s.elements {
+		if !e.Fixed {
+			return -1
+		}
+
+		for i := 0; i < e.Length; i++ {
+			if pos >= len(bban) {
+				return -1
+			}
+			if !matchClass(e.Class, bban[pos:pos+1]) {
+				return pos
+			}
+			pos++
+		}
+	}
+
+	if pos < len(bban) {
+		return pos
+	}
+
+	return -1
+}
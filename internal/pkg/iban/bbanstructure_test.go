@@ -0,0 +1,154 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseBBANStructure(t *testing.T) {
+	tests := []struct {
+		name          string
+		notation      string
+		wantElements  []bbanElement
+		wantMaxLength int
+		wantErr       bool
+	}{
+		{
+			name:     "fixed length elements",
+			notation: "4!a6!n8!c",
+			wantElements: []bbanElement{
+				{Length: 4, Fixed: true, Class: classAlpha},
+				{Length: 6, Fixed: true, Class: classNumeric},
+				{Length: 8, Fixed: true, Class: classAlphanumeric},
+			},
+			wantMaxLength: 18,
+		},
+		{
+			name:     "variable length elements",
+			notation: "12n2e",
+			wantElements: []bbanElement{
+				{Length: 12, Fixed: false, Class: classNumeric},
+				{Length: 2, Fixed: false, Class: classSpace},
+			},
+			wantMaxLength: 14,
+		},
+		{
+			name:     "fails for unknown character class",
+			notation: "4!x",
+			wantErr:  true,
+		},
+		{
+			name:     "fails for missing length",
+			notation: "4!n!a",
+			wantErr:  true,
+		},
+		{
+			name:     "fails for missing character class",
+			notation: "4!n6!",
+			wantErr:  true,
+		},
+		{
+			name:     "fails for zero length element",
+			notation: "0!n",
+			wantErr:  true,
+		},
+		{
+			name:     "fails for empty notation",
+			notation: "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := parseBBANStructure(tt.notation)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantElements, got.elements)
+			require.Equal(t, tt.wantMaxLength, got.MaxLength())
+			require.Equal(t, tt.notation, got.String())
+		})
+	}
+}
+
+func Test_bbanStructure_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		bban     string
+		want     bool
+	}{
+		{name: "GB matches", notation: "4!a6!n8!n", bban: "NWBK60161331926819", want: true},
+		{name: "lowercase letters fail", notation: "4!a6!n8!n", bban: "nwbk60161331926819", want: false},
+		{name: "digit instead of letter fails", notation: "4!a6!n8!n", bban: "NWB160161331926819", want: false},
+		{name: "too short fails", notation: "4!a6!n8!n", bban: "NWBK6016133192681", want: false},
+		{name: "too long fails", notation: "4!a6!n8!n", bban: "NWBK601613319268190", want: false},
+		{name: "FR matches", notation: "5!n5!n11!c2!n", bban: "20041010050500013M02606", want: true},
+		{name: "variable length matches shorter input", notation: "3n", bban: "12", want: true},
+		{name: "variable length fails for longer input", notation: "3n", bban: "1234", want: false},
+		{name: "variable length backtracks", notation: "3n2!n", bban: "1234", want: true},
+		{name: "variable length requires at least one character", notation: "3n2!n", bban: "12", want: false},
+		{name: "spaces", notation: "2!n2e", bban: "12 ", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.Equal(t, tt.want, mustParseBBANStructure(tt.notation).Match(tt.bban))
+		})
+	}
+}
+
+func Test_bbanStructure_FirstMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		bban     string
+		want     int
+	}{
+		{name: "match", notation: "4!a6!n8!n", bban: "NWBK60161331926819", want: -1},
+		{name: "digit instead of letter", notation: "4!a6!n8!n", bban: "NWB160161331926819", want: 3},
+		{name: "letter instead of digit", notation: "4!a6!n8!n", bban: "NWBK60161331926X19", want: 15},
+		{name: "first of several mismatches", notation: "4!a6!n8!n", bban: "NWBK6O161331926X19", want: 5},
+		{name: "too long", notation: "4!a6!n8!n", bban: "NWBK601613319268190", want: 18},
+		{name: "too short", notation: "4!a6!n8!n", bban: "NWBK6016133192681", want: -1},
+		{name: "after variable length element", notation: "3n2!a", bban: "12X4", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.Equal(t, tt.want, mustParseBBANStructure(tt.notation).FirstMismatch(tt.bban))
+		})
+	}
+}
+
+func Test_bbanStructure_Describe(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     string
+	}{
+		{name: "adjacent fixed elements are merged", notation: "4!a6!n8!n", want: "4 letters, 14 digits"},
+		{name: "singular", notation: "1!a5!n5!n12!c", want: "1 letter, 10 digits, 12 alphanumeric characters"},
+		{name: "variable length", notation: "2!n12n", want: "2 digits, up to 12 digits"},
+		{name: "spaces", notation: "1!e", want: "1 space"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.Equal(t, tt.want, mustParseBBANStructure(tt.notation).Describe())
+		})
+	}
+}
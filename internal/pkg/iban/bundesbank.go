@@ -0,0 +1,40 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/blz"
+)
+
+// WithBundesbankDirectory validates the account numbers of DE IBANs against the check method (Prüfziffermethode)
+// of the bank identified by the BBAN's bank code (Bankleitzahl), as published in the Bundesbank's bank code file.
+func WithBundesbankDirectory(dir *blz.Directory) Option {
+	return func(svc *Service) error {
+		svc.accountValidators["DE"] = germanAccountValidator(dir)
+		return nil
+	}
+}
+
+// germanAccountValidator validates the account number of a German BBAN (8 digits bank code, 10 digits account number).
+func germanAccountValidator(dir *blz.Directory) func(bban string) error {
+	return func(bban string) error {
+		if len(bban) != 18 {
+			return fmt.Errorf("%w: expected 18 characters", ErrIncorrectBBANFormat)
+		}
+
+		bankCode, accountNumber := bban[:8], bban[8:]
+		err := dir.ValidateAccount(bankCode, accountNumber)
+		switch {
+		case errors.Is(err, blz.ErrUnknownBankCode):
+			return fmt.Errorf("%w: %s", ErrUnknownBankCode, bankCode)
+		case errors.Is(err, blz.ErrCheckMethodNotSupported):
+			// we don't reject accounts we are unable to check, but we don't pretend to have checked them either
+			return fmt.Errorf("%w: %s", ErrAccountCheckNotSupported, err)
+		case err != nil:
+			return fmt.Errorf("%w: %s", ErrIncorrectAccountNumber, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package iban
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/blz"
+)
+
+func TestService_Validate_WithBundesbankDirectory(t *testing.T) {
+	dir := blz.NewDirectory([]blz.Bank{
+		{BankCode: "37040044", IsPaymentProvider: true, CheckMethod: "13"},
+		{BankCode: "10010010", IsPaymentProvider: true, CheckMethod: "E4"}, // not supported (yet)
+	})
+
+	tests := []struct {
+		name    string
+		ibanStr string
+		wantErr error
+	}{
+		{
+			name:    "valid account number",
+			ibanStr: "DE89370400440532013000",
+		},
+		{
+			name:    "invalid account number with valid IBAN checksum",
+			ibanStr: "DE08370400440532013100",
+			wantErr: ErrIncorrectAccountNumber,
+		},
+		{
+			name:    "unknown bank code",
+			ibanStr: "DE65123456780532013000",
+			wantErr: ErrUnknownBankCode,
+		},
+		{
+			name:    "unsupported check method is not rejected",
+			ibanStr: "DE84100100100532013000",
+		},
+	}
+
+	svc, err := NewService(WithBundesbankDirectory(dir))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.ErrorIs(t, svc.Validate(iban), tt.wantErr)
+		})
+	}
+}
+
+func TestService_ValidateAll_WithBundesbankDirectory(t *testing.T) {
+	dir := blz.NewDirectory([]blz.Bank{
+		{BankCode: "37040044", IsPaymentProvider: true, CheckMethod: "13"},
+		{BankCode: "10010010", IsPaymentProvider: true, CheckMethod: "E4"}, // not supported (yet)
+	})
+
+	tests := []struct {
+		name       string
+		ibanStr    string
+		wantStatus string
+	}{
+		{
+			name:       "supported check method",
+			ibanStr:    "DE89370400440532013000",
+			wantStatus: CheckStatusPassed,
+		},
+		{
+			name:       "unsupported check method is not reported as passed",
+			ibanStr:    "DE84100100100532013000",
+			wantStatus: CheckStatusMethodNotSupported,
+		},
+	}
+
+	svc, err := NewService(WithBundesbankDirectory(dir))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+
+			result := svc.ValidateAll(iban, time.Now())
+			require.NoError(t, result.Err())
+			for _, check := range result.Checks {
+				if check.Name == CheckBBANChecksum {
+					require.Equal(t, tt.wantStatus, check.Status)
+					require.Nil(t, check.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestNewService_WithBundesbankDirectory_WithoutDE(t *testing.T) {
+	pinned := `{"countries": [{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n"}]}`
+
+	_, err := NewService(WithBundesbankDirectory(blz.NewDirectory(nil)), WithRegistry(strings.NewReader(pinned)))
+	require.ErrorIs(t, err, ErrCountryCodeNotSupported)
+}
@@ -0,0 +1,40 @@
+package iban
+
+import (
+	"fmt"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/mod97"
+)
+
+// CheckDigits computes the two IBAN check digits for the country's bban, such that the resulting IBAN's mod97 is 1.
+// Ref: https://en.wikipedia.org/wiki/International_Bank_Account_Number#Generating_IBAN_check_digits
+func CheckDigits(countryCode, bban string) (string, error) {
+	if countryCode == "" {
+		return "", ErrCountryCodeEmpty
+	}
+	if bban == "" {
+		return "", ErrBBANEmpty
+	}
+	if !ibanRegexp.MatchString(countryCode + "00" + bban) {
+		return "", ErrIncorrectIbanFormat
+	}
+
+	checkDigits, err := mod97.CheckDigits(bban + countryCode)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert IBAN into numeric format: %w", err)
+	}
+
+	return checkDigits, nil
+}
+
+// Repair replaces the iban's check digits with the correct ones and validates the result. The repaired IBAN
+// is returned even if it is still invalid, along with the validation error, e.g. if its BBAN is faulty as well.
+func (svc *Service) Repair(i IBAN) (IBAN, error) {
+	checkDigits, err := CheckDigits(i.CountryCode, i.BBAN)
+	if err != nil {
+		return i, err
+	}
+
+	i.CheckDigits = checkDigits
+	return i, svc.Validate(i)
+}
@@ -0,0 +1,71 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDigits(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		bban        string
+		want        string
+		wantErr     error
+	}{
+		{name: "GB", countryCode: "GB", bban: "NWBK60161331926819", want: "29"},
+		{name: "DE", countryCode: "DE", bban: "370400440532013000", want: "89"},
+		{name: "leading zero", countryCode: "BE", bban: "539007547034", want: "68"},
+		{name: "unsupported country is computed nonetheless", countryCode: "US", bban: "123456789", want: "49"},
+		{name: "fails for empty country code", countryCode: "", bban: "NWBK60161331926819", wantErr: ErrCountryCodeEmpty},
+		{name: "fails for empty BBAN", countryCode: "GB", bban: "", wantErr: ErrBBANEmpty},
+		{name: "fails for lowercase letters", countryCode: "GB", bban: "nwbk60161331926819", wantErr: ErrIncorrectIbanFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := CheckDigits(tt.countryCode, tt.bban)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Repair(t *testing.T) {
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    string
+		wantErr error
+	}{
+		{name: "incorrect check digits are replaced", ibanStr: "GB00NWBK60161331926819", want: "GB29NWBK60161331926819"},
+		{name: "correct check digits are kept", ibanStr: "GB29NWBK60161331926819", want: "GB29NWBK60161331926819"},
+		{
+			name:    "other faults are reported",
+			ibanStr: "FR0020041010050500013M02607",
+			want:    "FR8420041010050500013M02607",
+			wantErr: ErrIncorrectBBANChecksum,
+		},
+		{name: "unsupported country", ibanStr: "US00123456789", want: "US49123456789", wantErr: ErrCountryCodeNotSupported},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+
+			got, err := svc.Repair(iban)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Checks ValidateAll runs, in the order they are run and reported.
+const (
+	CheckCountry       = "country"        // the IBAN has a country code and BBAN, and the country is supported on the validation date
+	CheckLength        = "length"         // the IBAN has the country's length
+	CheckIBANChecksum  = "iban_checksum"  // the check digits match the ISO 7064 mod 97-10 checksum
+	CheckBBANFormat    = "bban_format"    // the BBAN has the country's structure
+	CheckBBANChecksum  = "bban_checksum"  // the national check digits and, if configured, the account are valid
+	CheckBankDirectory = "bank_directory" // the bank exists in the bank directory
+)
+
+// Outcomes of a Check.
+const (
+	CheckStatusPassed  = "passed"
+	CheckStatusFailed  = "failed"
+	CheckStatusSkipped = "skipped" // the check did not run, e.g. because the country is not supported or the level excludes it
+	// CheckStatusMethodNotSupported means the national check digits are valid, but the account number could not be
+	// checked because the bank's check method is not supported. It does not render the IBAN invalid.
+	CheckStatusMethodNotSupported = "method_not_supported"
+)
+
+// Check is the outcome of a single validation check of an IBAN.
+// swagger:model
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Error and ErrorDetails describe why the check failed, only set if it failed.
+	Error        *string          `json:"error,omitempty"`
+	ErrorDetails *ValidationError `json:"error_details,omitempty"`
+
+	err error
+}
+
+// Err returns the error of the check, nil if it did not fail.
+func (c Check) Err() error {
+	return c.err
+}
+
+// ValidationResult is the outcome of every check of an IBAN.
+type ValidationResult struct {
+	Checks []Check
+}
+
+// Err returns the error of the first failed check, nil if the IBAN is valid.
+func (r ValidationResult) Err() error {
+	for _, check := range r.Checks {
+		if check.err != nil {
+			return check.err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAll runs every check of the Service's level on the iban, using the rules that were in effect on the day
+// of t, instead of stopping at the first failure like ValidateAt.
+func (svc *Service) ValidateAll(i IBAN, t time.Time) ValidationResult {
+	return svc.validateLevel(i, t, svc.level)
+}
+
+// ValidateLevel runs every check of the given level on the iban, using the rules that were in effect on the day of t.
+// The zero Level stands for the Service's level. It fails with ErrUnknownLevel for other levels than the known ones,
+// and with ErrBankDirectoryRequired for LevelDirectory if the Service has no bank directory.
+func (svc *Service) ValidateLevel(i IBAN, t time.Time, level Level) (ValidationResult, error) {
+	if level == "" {
+		return svc.validateLevel(i, t, svc.level), nil
+	}
+
+	level, err := ParseLevel(string(level))
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if level == LevelDirectory && svc.bankDirectory == nil {
+		return ValidationResult{}, fmt.Errorf("%w: %s", ErrBankDirectoryRequired, level)
+	}
+
+	return svc.validateLevel(i, t, level), nil
+}
+
+// validateLevel runs every check of the valid level on the iban, using the rules that were in effect on the day of t.
+// The checks the level excludes are skipped, as are the checks of the country's rules if the country is not supported,
+// and the checks relying on the BBAN components if the BBAN has the wrong format.
+func (svc *Service) validateLevel(i IBAN, t time.Time, level Level) ValidationResult {
+	if level == "" {
+		level = LevelNational
+	}
+
+	result := ValidationResult{}
+	add := func(name string, err error) {
+		check := Check{Name: name, Status: CheckStatusPassed, err: err}
+		if err != nil {
+			check.Status = CheckStatusFailed
+			check.Error = errorString(err)
+			errors.As(err, &check.ErrorDetails)
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	skip := func(names ...string) {
+		for _, name := range names {
+			result.Checks = append(result.Checks, Check{Name: name, Status: CheckStatusSkipped})
+		}
+	}
+
+	validator, err := svc.validatorFor(i, t)
+	add(CheckCountry, err)
+	if err != nil {
+		skip(CheckLength, CheckIBANChecksum, CheckBBANFormat, CheckBBANChecksum, CheckBankDirectory)
+		return result
+	}
+
+	if err = validator.ValidateIbanLength(i); err != nil {
+		err = fmt.Errorf("iban length validation error: %w", validator.locate(i, err))
+	}
+	add(CheckLength, err)
+
+	if level.includes(LevelChecksum) {
+		if err = validator.ValidateIbanChecksum(i); err != nil {
+			err = fmt.Errorf("iban checksum validation error: %w", validator.locate(i, err))
+		}
+		add(CheckIBANChecksum, err)
+	} else {
+		skip(CheckIBANChecksum)
+	}
+
+	if err = validator.ValidateBbanFormat(i); err != nil {
+		add(CheckBBANFormat, fmt.Errorf("bban format validation error: %w", validator.locate(i, err)))
+		skip(CheckBBANChecksum, CheckBankDirectory)
+		return result
+	}
+	add(CheckBBANFormat, nil)
+
+	if level.includes(LevelNational) {
+		err = validator.ValidateBbanChecksum(i)
+		switch {
+		case errors.Is(err, ErrAccountCheckNotSupported):
+			result.Checks = append(result.Checks, Check{Name: CheckBBANChecksum, Status: CheckStatusMethodNotSupported})
+		case err != nil:
+			add(CheckBBANChecksum, fmt.Errorf("bban checksum validation error: %w", validator.locate(i, err)))
+		default:
+			add(CheckBBANChecksum, nil)
+		}
+	} else {
+		skip(CheckBBANChecksum)
+	}
+
+	if level.includes(LevelDirectory) {
+		if err = svc.validateBank(validator.Annotate(i)); err != nil {
+			err = fmt.Errorf("bank directory validation error: %w", validator.locate(i, err))
+		}
+		add(CheckBankDirectory, err)
+	} else {
+		skip(CheckBankDirectory)
+	}
+
+	return result
+}
+
+// validatorFor returns the rules of the iban's country that were in effect on the day of t.
+func (svc *Service) validatorFor(i IBAN, t time.Time) (countryValidator, error) {
+	if i.CountryCode == "" {
+		return countryValidator{}, newValidationError(ErrCountryCodeEmpty, ComponentCountryCode, -1)
+	}
+	if i.BBAN == "" {
+		return countryValidator{}, newValidationError(ErrBBANEmpty, ComponentBBAN, -1)
+	}
+
+	validator, ok := svc.validator(i.CountryCode)
+	if !ok {
+		return countryValidator{}, newValidationError(ErrCountryCodeNotSupported, ComponentCountryCode, 0)
+	}
+
+	validator, ok = validator.At(t)
+	if !ok {
+		return countryValidator{}, newValidationError(ErrCountryCodeNotEffective, ComponentCountryCode, 0)
+	}
+
+	return validator, nil
+}
@@ -0,0 +1,200 @@
+package iban
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+)
+
+func TestService_ValidateAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		i       IBAN
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name: "all checks pass",
+			i:    IBAN{CountryCode: "GB", CheckDigits: "29", BBAN: "NWBK60161331926819"},
+			want: map[string]string{
+				CheckCountry:       CheckStatusPassed,
+				CheckLength:        CheckStatusPassed,
+				CheckIBANChecksum:  CheckStatusPassed,
+				CheckBBANFormat:    CheckStatusPassed,
+				CheckBBANChecksum:  CheckStatusPassed,
+				CheckBankDirectory: CheckStatusSkipped,
+			},
+		},
+		{
+			name: "reports every failure",
+			i:    IBAN{CountryCode: "GB", CheckDigits: "29", BBAN: "NWBK6016133192681"},
+			want: map[string]string{
+				CheckCountry:       CheckStatusPassed,
+				CheckLength:        CheckStatusFailed,
+				CheckIBANChecksum:  CheckStatusFailed,
+				CheckBBANFormat:    CheckStatusFailed,
+				CheckBBANChecksum:  CheckStatusSkipped,
+				CheckBankDirectory: CheckStatusSkipped,
+			},
+			wantErr: ErrIncorrectLength,
+		},
+		{
+			name: "runs the BBAN checks despite an incorrect IBAN checksum",
+			i:    IBAN{CountryCode: "GB", CheckDigits: "92", BBAN: "NWBK60161331926819"},
+			want: map[string]string{
+				CheckCountry:       CheckStatusPassed,
+				CheckLength:        CheckStatusPassed,
+				CheckIBANChecksum:  CheckStatusFailed,
+				CheckBBANFormat:    CheckStatusPassed,
+				CheckBBANChecksum:  CheckStatusPassed,
+				CheckBankDirectory: CheckStatusSkipped,
+			},
+			wantErr: ErrIncorrectIBANChecksum,
+		},
+		{
+			name: "skips the country's checks if it is not supported",
+			i:    IBAN{CountryCode: "XX", CheckDigits: "29", BBAN: "NWBK60161331926819"},
+			want: map[string]string{
+				CheckCountry:       CheckStatusFailed,
+				CheckLength:        CheckStatusSkipped,
+				CheckIBANChecksum:  CheckStatusSkipped,
+				CheckBBANFormat:    CheckStatusSkipped,
+				CheckBBANChecksum:  CheckStatusSkipped,
+				CheckBankDirectory: CheckStatusSkipped,
+			},
+			wantErr: ErrCountryCodeNotSupported,
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			result := svc.ValidateAll(tt.i, time.Now())
+			require.ErrorIs(t, result.Err(), tt.wantErr)
+
+			got := map[string]string{}
+			for _, check := range result.Checks {
+				got[check.Name] = check.Status
+				require.Equal(t, check.Status == CheckStatusFailed, check.Error != nil)
+			}
+			require.Equal(t, tt.want, got)
+			require.Len(t, result.Checks, len(tt.want))
+		})
+	}
+}
+
+func TestService_ValidateLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		ibanStr string
+		level   Level
+		skipped []string
+		wantErr error
+	}{
+		{
+			name:    "syntax skips the IBAN checksum",
+			ibanStr: "GB92NWBK60161331926819",
+			level:   LevelSyntax,
+			skipped: []string{CheckIBANChecksum, CheckBBANChecksum, CheckBankDirectory},
+		},
+		{
+			name:    "checksum checks the IBAN checksum",
+			ibanStr: "GB92NWBK60161331926819",
+			level:   LevelChecksum,
+			skipped: []string{CheckBBANChecksum, CheckBankDirectory},
+			wantErr: ErrIncorrectIBANChecksum,
+		},
+		{
+			name:    "checksum skips the national checksum",
+			ibanStr: "FR8420041010050500013M02607",
+			level:   LevelChecksum,
+			skipped: []string{CheckBBANChecksum, CheckBankDirectory},
+		},
+		{
+			name:    "national checks the national checksum",
+			ibanStr: "FR8420041010050500013M02607",
+			level:   LevelNational,
+			skipped: []string{CheckBankDirectory},
+			wantErr: ErrIncorrectBBANChecksum,
+		},
+		{
+			name:    "national skips the bank directory",
+			ibanStr: "GB33BUKB20201555555555",
+			level:   LevelNational,
+			skipped: []string{CheckBankDirectory},
+		},
+		{
+			name:    "directory checks that the bank exists",
+			ibanStr: "GB33BUKB20201555555555",
+			level:   LevelDirectory,
+			wantErr: ErrUnknownBankCode,
+		},
+		{
+			name:    "directory passes for a known bank",
+			ibanStr: "GB29NWBK60161331926819",
+			level:   LevelDirectory,
+		},
+	}
+
+	dir := bankdir.NewDirectory([]bankdir.Bank{{CountryCode: "GB", BankCode: "NWBK", Name: "National Westminster Bank"}})
+	svc, err := NewService(WithBankDirectory(dir))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+
+			result, err := svc.ValidateLevel(iban, time.Now(), tt.level)
+			require.NoError(t, err)
+			require.ErrorIs(t, result.Err(), tt.wantErr)
+
+			var skipped []string
+			for _, check := range result.Checks {
+				if check.Status == CheckStatusSkipped {
+					skipped = append(skipped, check.Name)
+				}
+			}
+			require.Equal(t, tt.skipped, skipped)
+		})
+	}
+}
+
+func TestNewService_WithLevel(t *testing.T) {
+	_, err := NewService(WithLevel("everything"))
+	require.ErrorIs(t, err, ErrUnknownLevel)
+
+	_, err = NewService(WithLevel(LevelDirectory))
+	require.ErrorIs(t, err, ErrBankDirectoryRequired)
+
+	svc, err := NewService(WithLevel(LevelSyntax))
+	require.NoError(t, err)
+
+	iban, err := svc.Parse("GB92NWBK60161331926819")
+	require.NoError(t, err)
+	require.NoError(t, svc.Validate(iban))
+
+	result, err := svc.ValidateLevel(iban, time.Now(), "")
+	require.NoError(t, err)
+	require.NoError(t, result.Err())
+
+	result, err = svc.ValidateLevel(iban, time.Now(), LevelChecksum)
+	require.NoError(t, err)
+	require.ErrorIs(t, result.Err(), ErrIncorrectIBANChecksum)
+
+	_, err = svc.ValidateLevel(iban, time.Now(), "everything")
+	require.ErrorIs(t, err, ErrUnknownLevel)
+
+	_, err = svc.ValidateLevel(iban, time.Now(), LevelDirectory)
+	require.ErrorIs(t, err, ErrBankDirectoryRequired)
+}
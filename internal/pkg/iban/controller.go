@@ -2,12 +2,16 @@ package iban
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	server "github.com/ymakhloufi/pfc/internal/http"
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
 	"go.uber.org/zap"
 )
 
@@ -17,25 +21,50 @@ var (
 	// faster to compile once, rather than each request.
 	// Downside: if it fails, it panics the whole server on startup,
 	// instead of doing regexp.Compile() and handling the returned error gracefully.
-	validateEndpointRegexp = regexp.MustCompile(`^/v1/iban/([^/?]+)/validate/?$`)
+	validateEndpointRegexp    = regexp.MustCompile(`^/v1/iban/([^/?]+)/validate/?$`)
+	generateEndpointRegexp    = regexp.MustCompile(`^/v1/iban/generate/?$`)
+	extractEndpointRegexp     = regexp.MustCompile(`^/v1/iban/extract/?$`)
+	checkDigitsEndpointRegexp = regexp.MustCompile(`^/v1/iban/check-digits/?$`)
+	repairEndpointRegexp      = regexp.MustCompile(`^/v1/iban/([^/?]+)/repair/?$`)
+	bicEndpointRegexp         = regexp.MustCompile(`^/v1/iban/([^/?]+)/bic/([^/?]+)/validate/?$`)
 )
 
-// Parser can parse an iban string into an IBAN struct and validate its components.
+// Parser can parse an iban string into an IBAN struct, validate its components, suggest corrections,
+// repair its check digits, generate it from national details, mask it, cross-check it with a BIC
+// and extract IBANs from text.
 type Parser interface {
 	Parse(iban string) (IBAN, error)
+	ParseAt(iban string, t time.Time) (IBAN, error)
 	Validate(IBAN) error
+	ValidateLevel(IBAN, time.Time, Level) (ValidationResult, error)
+	Suggest(IBAN) []Suggestion
+	Repair(IBAN) (IBAN, error)
+	Generate(NationalDetails) (IBAN, error)
+	Mask(IBAN, MaskPolicy) string
+	CrossCheck(IBAN, bic.BIC) error
+	Extract(text string) []Match
 }
 
+// maxExtractBodySize is the largest text the extract endpoint accepts.
+const maxExtractBodySize = 1 << 20
+
 // Controller the iban controller that adds routes to the http server.
 type Controller struct {
-	parser Parser
-	logger *zap.Logger
+	parser    Parser
+	bicParser bic.Parser
+	logger    *zap.Logger
+
+	// logMaskPolicy masks the IBANs written to the logs, the zero value hides all characters.
+	logMaskPolicy MaskPolicy
 }
 
-func NewController(parser Parser, logger *zap.Logger) *Controller {
+// NewController creates the iban controller, which logs the IBANs it handles masked by the logMaskPolicy.
+func NewController(parser Parser, bicParser bic.Parser, logMaskPolicy MaskPolicy, logger *zap.Logger) *Controller {
 	return &Controller{
-		parser: parser,
-		logger: logger,
+		parser:        parser,
+		bicParser:     bicParser,
+		logger:        logger,
+		logMaskPolicy: logMaskPolicy,
 	}
 }
 
@@ -48,8 +77,24 @@ func (ctrl Controller) SetupRoutes() {
 		case r.Method == http.MethodGet && validateEndpointRegexp.MatchString(path): // /iban/<iban>/validate
 			ctrl.validate(w, r)
 			return
+		case r.Method == http.MethodGet && repairEndpointRegexp.MatchString(path): // /iban/<iban>/repair
+			ctrl.repair(w, r)
+			return
+		case r.Method == http.MethodGet && bicEndpointRegexp.MatchString(path): // /iban/<iban>/bic/<bic>/validate
+			ctrl.crossCheck(w, r)
+			return
+		case r.Method == http.MethodGet && checkDigitsEndpointRegexp.MatchString(path): // /iban/check-digits
+			ctrl.checkDigits(w, r)
+			return
+		case r.Method == http.MethodPost && generateEndpointRegexp.MatchString(path): // /iban/generate
+			ctrl.generate(w, r)
+			return
+		case r.Method == http.MethodPost && extractEndpointRegexp.MatchString(path): // /iban/extract
+			ctrl.extract(w, r)
+			return
 		default:
-			ctrl.writeResponse(w, nil, fmt.Errorf("unsupported route: %s", path), http.StatusNotFound)
+			// the path is left out, as it may contain an unmasked IBAN
+			ctrl.writeResponse(w, newHTTPResponse(nil, errors.New("unsupported route")), http.StatusNotFound)
 		}
 	})
 }
@@ -57,6 +102,7 @@ func (ctrl Controller) SetupRoutes() {
 // swagger:operation GET /v1/iban/{iban}/validate validateIBAN
 //
 // # Validates a given IBAN string and returns its validity, components and a possible error message.
+// If the IBAN's checksum is incorrect, IBANs it was probably mistyped from are suggested.
 //
 // ---
 // parameters:
@@ -64,6 +110,23 @@ func (ctrl Controller) SetupRoutes() {
 //     name: iban
 //     required: true
 //     type: string
+//   - in: query
+//     name: as_of
+//     description: parse and validate with the rules that were in effect on this date (YYYY-MM-DD), defaults to today
+//     required: false
+//     type: string
+//     format: date
+//   - in: query
+//     name: strict
+//     description: if true, reject IBAN strings that are not in their canonical electronic form instead of normalizing them
+//     required: false
+//     type: boolean
+//   - in: query
+//     name: level
+//     description: how deep to validate, one of syntax, checksum, national or directory, defaults to the service's level
+//     required: false
+//     type: string
+//     enum: [syntax, checksum, national, directory]
 //
 // responses:
 //
@@ -71,66 +134,361 @@ func (ctrl Controller) SetupRoutes() {
 //    description: IBAN was successfully validated, result can be positive or negative
 //    schema:
 //      $ref: '#/definitions/httpResponse'
+//	'400':
+//    description: as_of is not a valid date, level is unknown or level is directory without a configured bank directory
+//    schema:
+//      $ref: '#/definitions/httpResponse'
 //	'422':
-//    description: IBAN string could not be parsed, i.e. has a wrong format (Ref https://en.wikipedia.org/wiki/International_Bank_Account_Number#Structure)
+//    description: IBAN string could not be parsed, i.e. has a wrong format (Ref https://en.wikipedia.org/wiki/International_Bank_Account_Number#Structure), or is not canonical in strict mode
 //    schema:
 //      $ref: '#/definitions/httpResponse'
 //	'500':
 //	  description: Internal Server Error
 
-// validate parses and validates the iban string.
+// validate normalizes, parses and validates the iban string.
 func (ctrl Controller) validate(w http.ResponseWriter, r *http.Request) {
-	ibanStr := validateEndpointRegexp.FindStringSubmatch(r.URL.Path)[1]
-	ibanStr = strings.Replace(ibanStr, " ", "", -1)
-	ibanStr = strings.ToUpper(ibanStr)
+	rawIBAN := validateEndpointRegexp.FindStringSubmatch(r.URL.Path)[1]
+	ibanStr, normalizations := Normalize(rawIBAN)
+	if r.URL.Query().Get("strict") == "true" {
+		if _, err := NormalizeStrict(rawIBAN); err != nil {
+			ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusUnprocessableEntity)
+			return
+		}
+	}
 
-	iban, err := ctrl.parser.Parse(ibanStr)
+	asOf := time.Now()
+	if asOfStr := r.URL.Query().Get("as_of"); asOfStr != "" {
+		var err error
+		asOf, err = time.Parse(dateLayout, asOfStr)
+		if err != nil {
+			err = fmt.Errorf("invalid as_of date %q, expected format %s", asOfStr, dateLayout)
+			ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	iban, err := ctrl.parser.ParseAt(ibanStr, asOf)
 	if err != nil {
 		ctrl.logger.Error("request failed", zap.Error(err))
-		ctrl.writeResponse(w, nil, err, http.StatusUnprocessableEntity)
+		response := newHTTPResponse(nil, err)
+		response.Normalizations = normalizations
+		ctrl.writeResponse(w, response, http.StatusUnprocessableEntity)
 		return
 	}
 
-	err = ctrl.parser.Validate(iban)
+	// the level is checked by the parser, as it knows whether a bank directory is configured
+	result, err := ctrl.parser.ValidateLevel(iban, asOf, Level(r.URL.Query().Get("level")))
 	if err != nil {
-		ctrl.writeResponse(w, &iban, err, http.StatusOK) // failed validation is an expected outcome, thus 200.
+		ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusBadRequest)
 		return
 	}
+	err = result.Err()
+
+	response := newHTTPResponse(&iban, err)
+	response.Normalizations = normalizations
+	response.Checks = result.Checks
+	if errors.Is(err, ErrIncorrectIBANChecksum) {
+		response.Suggestions = ctrl.parser.Suggest(iban)
+	}
 
-	ctrl.writeResponse(w, &iban, nil, http.StatusOK)
+	ctrl.writeResponse(w, response, http.StatusOK) // failed validation is an expected outcome, thus 200.
 }
 
-// writeResponse writes the response to the http response writer.
-func (ctrl Controller) writeResponse(w http.ResponseWriter, iban *IBAN, err error, status int) {
-	var errStr *string
+// swagger:operation GET /v1/iban/{iban}/repair repairIBAN
+//
+// # Replaces the check digits of a given IBAN string with the correct ones and validates the repaired IBAN.
+//
+// ---
+// parameters:
+//   - in: path
+//     name: iban
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//    description: IBAN was successfully repaired, the repaired IBAN can still be invalid if it has other faults
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'422':
+//    description: IBAN string could not be parsed, i.e. has a wrong format (Ref https://en.wikipedia.org/wiki/International_Bank_Account_Number#Structure)
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// repair parses the iban string and corrects its check digits.
+func (ctrl Controller) repair(w http.ResponseWriter, r *http.Request) {
+	ibanStr, normalizations := Normalize(repairEndpointRegexp.FindStringSubmatch(r.URL.Path)[1])
+
+	iban, err := ctrl.parser.Parse(ibanStr)
 	if err != nil {
-		e := err.Error()
-		errStr = &e
+		response := newHTTPResponse(nil, err)
+		response.Normalizations = normalizations
+		ctrl.writeResponse(w, response, http.StatusUnprocessableEntity)
+		return
 	}
 
-	response := httpResponse{Error: errStr, IsValid: err == nil, IBAN: iban}
-	l := ctrl.logger.With(
-		zap.Any("iban", iban),
-		zap.Error(err),
-		zap.Int("status", status),
-		zap.Any("response", response),
-	)
+	iban, err = ctrl.parser.Repair(iban)
+	response := newHTTPResponse(&iban, err)
+	response.Normalizations = normalizations
+	ctrl.writeResponse(w, response, http.StatusOK) // a repaired IBAN with other faults is an expected outcome, thus 200.
+}
+
+// swagger:operation POST /v1/iban/generate generateIBAN
+//
+// # Generates an IBAN from national account details, e.g. a bank code and account number, and returns its components.
+//
+// ---
+// parameters:
+//   - in: body
+//     name: details
+//     required: true
+//     schema:
+//       $ref: '#/definitions/NationalDetails'
+//
+// responses:
+//
+//	'200':
+//    description: IBAN was successfully generated
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'400':
+//    description: request body is not a valid JSON object
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'422':
+//    description: no valid IBAN can be generated from the national details
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// generate generates an iban from the national details in the request body.
+func (ctrl Controller) generate(w http.ResponseWriter, r *http.Request) {
+	var details NationalDetails
+	err := json.NewDecoder(r.Body).Decode(&details)
+	if err != nil {
+		ctrl.writeResponse(w, newHTTPResponse(nil, fmt.Errorf("failed to decode request body: %w", err)), http.StatusBadRequest)
+		return
+	}
+
+	details.CountryCode = strings.ToUpper(details.CountryCode)
+	details.BankCode = strings.ToUpper(strings.Replace(details.BankCode, " ", "", -1))
+	details.BranchCode = strings.ToUpper(strings.Replace(details.BranchCode, " ", "", -1))
+	details.AccountNumber = strings.ToUpper(strings.Replace(details.AccountNumber, " ", "", -1))
+	details.NationalCheckDigits = strings.ToUpper(strings.Replace(details.NationalCheckDigits, " ", "", -1))
+
+	iban, err := ctrl.parser.Generate(details)
+	if err != nil {
+		ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ctrl.writeResponse(w, newHTTPResponse(&iban, nil), http.StatusOK)
+}
+
+// swagger:operation POST /v1/iban/extract extractIBANs
+//
+// # Finds the IBANs in plain text, e.g. an invoice, email or OCR output, and returns their positions and validity.
+//
+// ---
+// consumes:
+//   - text/plain
+// parameters:
+//   - in: body
+//     name: text
+//     required: true
+//     schema:
+//       type: string
+//
+// responses:
+//
+//	'200':
+//    description: text was successfully scanned, the IBANs found can be valid or invalid
+//    schema:
+//      $ref: '#/definitions/extractHTTPResponse'
+//	'400':
+//    description: request body could not be read or is larger than 1 MiB
+//    schema:
+//      $ref: '#/definitions/extractHTTPResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// extract finds the ibans in the text of the request body. The ibans found are not logged, as they are unmasked.
+func (ctrl Controller) extract(w http.ResponseWriter, r *http.Request) {
+	text, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxExtractBodySize))
+	if err != nil {
+		err = fmt.Errorf("failed to read request body: %w", err)
+		server.WriteJSON(w, ctrl.logger.With(zap.Error(err)), extractHTTPResponse{Error: errorString(err)}, http.StatusBadRequest)
+		return
+	}
+
+	response := extractHTTPResponse{Matches: []extractedIBAN{}}
+	for _, match := range ctrl.parser.Extract(string(text)) {
+		iban := match.IBAN
+		response.Matches = append(response.Matches, extractedIBAN{
+			Start:        match.Start,
+			End:          match.End,
+			Text:         match.Text,
+			httpResponse: newHTTPResponse(&iban, match.Err),
+		})
+	}
+
+	server.WriteJSON(w, ctrl.logger.With(zap.Int("matches", len(response.Matches))), response, http.StatusOK)
+}
+
+// swagger:operation GET /v1/iban/check-digits checkDigitsIBAN
+//
+// # Computes the IBAN check digits for a country code and BBAN.
+//
+// ---
+// parameters:
+//   - in: query
+//     name: country_code
+//     required: true
+//     type: string
+//   - in: query
+//     name: bban
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//    description: check digits were successfully computed
+//    schema:
+//      $ref: '#/definitions/checkDigitsHTTPResponse'
+//	'422':
+//    description: country code or BBAN is empty or does not satisfy the IBAN format
+//    schema:
+//      $ref: '#/definitions/checkDigitsHTTPResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// checkDigits computes the check digits for the country code and bban query parameters.
+// The bban is not logged, as it contains the unmasked account number.
+func (ctrl Controller) checkDigits(w http.ResponseWriter, r *http.Request) {
+	countryCode := strings.ToUpper(r.URL.Query().Get("country_code"))
+	bban := strings.ToUpper(strings.Replace(r.URL.Query().Get("bban"), " ", "", -1))
+	response := checkDigitsHTTPResponse{CountryCode: countryCode, BBAN: bban}
+	l := ctrl.logger.With(zap.String("country_code", countryCode))
+
+	checkDigits, err := CheckDigits(countryCode, bban)
+	if err != nil {
+		response.Error = errorString(err)
+		server.WriteJSON(w, l.With(zap.Error(err)), response, http.StatusUnprocessableEntity)
+		return
+	}
+
+	response.CheckDigits = checkDigits
+	response.IBAN = countryCode + checkDigits + bban
+	server.WriteJSON(w, l, response, http.StatusOK)
+}
+
+// swagger:operation GET /v1/iban/{iban}/bic/{bic}/validate crossCheckIBAN
+//
+// # Validates a given IBAN string and checks that the given BIC belongs to the IBAN's country and, if known, its bank.
+//
+// ---
+// parameters:
+//   - in: path
+//     name: iban
+//     required: true
+//     type: string
+//   - in: path
+//     name: bic
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//    description: IBAN and BIC were successfully cross-checked, result can be positive or negative
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'422':
+//    description: IBAN or BIC string could not be parsed
+//    schema:
+//      $ref: '#/definitions/httpResponse'
+//	'500':
+//	  description: Internal Server Error
 
-	jsonResponse, err := json.Marshal(response)
+// crossCheck parses and validates the iban string and checks that the bic string matches it.
+func (ctrl Controller) crossCheck(w http.ResponseWriter, r *http.Request) {
+	matches := bicEndpointRegexp.FindStringSubmatch(r.URL.Path)
+	ibanStr, normalizations := Normalize(matches[1])
+	bicStr := strings.ToUpper(strings.Replace(matches[2], " ", "", -1))
+
+	iban, err := ctrl.parser.Parse(ibanStr)
 	if err != nil {
-		l.Error("failed to marshal response", zap.Error(err))
-		err = fmt.Errorf("failed to marshal response: %w", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response := newHTTPResponse(nil, err)
+		response.Normalizations = normalizations
+		ctrl.writeResponse(w, response, http.StatusUnprocessableEntity)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_, err = w.Write(jsonResponse)
+	b, err := ctrl.bicParser.Parse(bicStr)
 	if err != nil {
-		l.Error("failed to write response", zap.Error(err))
-		err = fmt.Errorf("failed to write response: %w", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response := newHTTPResponse(&iban, fmt.Errorf("invalid bic: %w", err))
+		response.Normalizations = normalizations
+		ctrl.writeResponse(w, response, http.StatusUnprocessableEntity)
 		return
 	}
+
+	err = ctrl.parser.Validate(iban)
+	if err == nil {
+		err = ctrl.parser.CrossCheck(iban, b)
+	}
+
+	response := newHTTPResponse(&iban, err)
+	response.Normalizations = normalizations
+	response.BIC = &b
+	response.MismatchReason = mismatchReason(err)
+	ctrl.writeResponse(w, response, http.StatusOK) // a failed validation or mismatch is an expected outcome, thus 200.
+}
+
+// newHTTPResponse creates the response for the iban and the error of its processing, if any.
+func newHTTPResponse(iban *IBAN, err error) httpResponse {
+	response := httpResponse{Error: errorString(err), IsValid: err == nil, IBAN: iban}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		response.ErrorDetails = validationErr
+	}
+	if iban != nil {
+		formats := iban.Formats()
+		response.Formats = &formats
+	}
+
+	return response
+}
+
+// errorString returns the message of err, or nil if there is no error.
+func errorString(err error) *string {
+	if err == nil {
+		return nil
+	}
+
+	e := err.Error()
+	return &e
+}
+
+// writeResponse writes the response to the http response writer.
+// The response is not logged as a whole, as it contains the unmasked IBAN and account number.
+func (ctrl Controller) writeResponse(w http.ResponseWriter, response httpResponse, status int) {
+	var maskedIBAN *string
+	if response.IBAN != nil {
+		masked := ctrl.parser.Mask(*response.IBAN, ctrl.logMaskPolicy)
+		maskedIBAN = &masked
+	}
+
+	l := ctrl.logger.With(
+		zap.Stringp("iban", maskedIBAN),
+		zap.Stringp("error", response.Error),
+		zap.Int("status", status),
+		zap.Bool("is_valid", response.IsValid),
+	)
+
+	server.WriteJSON(w, l, response, status)
 }
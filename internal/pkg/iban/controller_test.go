@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestController_writeResponse(t *testing.T) {
@@ -29,7 +33,23 @@ func TestController_writeResponse(t *testing.T) {
 				err:    nil,
 				status: http.StatusOK,
 			},
-			want: `{"error":null,"is_valid":true,"iban":{"country_code":"NL","check_digits":"12","bban":"112233"}}`,
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"12","bban":"112233"},"formats":{"electronic":"NL12112233","print":"NL12 1122 33"}}`,
+		},
+		{
+			name: "success with BBAN components",
+			args: args{
+				iban: &IBAN{
+					CountryCode:   "GB",
+					CheckDigits:   "29",
+					BBAN:          "NWBK60161331926819",
+					BankCode:      "NWBK",
+					BranchCode:    "601613",
+					AccountNumber: "31926819",
+				},
+				err:    nil,
+				status: http.StatusOK,
+			},
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"GB","check_digits":"29","bban":"NWBK60161331926819","bank_code":"NWBK","branch_code":"601613","account_number":"31926819"},"formats":{"electronic":"GB29NWBK60161331926819","print":"GB29 NWBK 6016 1331 9268 19","national":"sort code 60-16-13, account 31926819"}}`,
 		},
 		{
 			name: "error with IBAN object",
@@ -38,7 +58,7 @@ func TestController_writeResponse(t *testing.T) {
 				err:    errors.New("some error"),
 				status: http.StatusTeapot,
 			},
-			want: `{"error":"some error","is_valid":false,"iban":{"country_code":"NL","check_digits":"12","bban":"112233"}}`,
+			want: `{"error":"some error","is_valid":false,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"12","bban":"112233"},"formats":{"electronic":"NL12112233","print":"NL12 1122 33"}}`,
 		},
 		{
 			name: "error without IBAN object",
@@ -55,14 +75,39 @@ func TestController_writeResponse(t *testing.T) {
 			tt := tt // shadow tt for parallel execution
 			t.Parallel()
 
-			crtl := Controller{parser: nil, logger: zap.NewNop()}
+			crtl := Controller{parser: &mockParser{t: t}, logger: zap.NewNop()}
 			w := httptest.NewRecorder()
-			crtl.writeResponse(w, tt.args.iban, tt.args.err, tt.args.status)
+			crtl.writeResponse(w, newHTTPResponse(tt.args.iban, tt.args.err), tt.args.status)
 			require.JSONEq(t, tt.want, w.Body.String())
 		})
 	}
 }
 
+func TestController_writeResponse_MasksLoggedIBAN(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+	core, logs := observer.New(zap.InfoLevel)
+	ctrl := Controller{parser: svc, logger: zap.New(core), logMaskPolicy: MaskPolicyBankCode}
+
+	iban := &IBAN{CountryCode: "DE", CheckDigits: "89", BBAN: "370400440532013000", BankCode: "37040044", AccountNumber: "0532013000"}
+	w := &failingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+	ctrl.writeResponse(w, newHTTPResponse(iban, nil), http.StatusOK)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	require.Equal(t, "DE8937040044**********", fields["iban"])
+	require.NotContains(t, fmt.Sprint(fields), "0532013000")
+}
+
+// failingResponseWriter fails to write the body, so that the response is logged.
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *failingResponseWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection closed")
+}
+
 func TestController_validate(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -74,13 +119,13 @@ func TestController_validate(t *testing.T) {
 		{
 			name: "success",
 			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate", nil),
-			want: `{"error":null,"is_valid":true,"iban":{"country_code":"NL","check_digits":"22","bban":"555566667777"}}`,
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"}}`,
 			parser: &mockParser{
-				ParseFunc: func(s string) (IBAN, error) {
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
 					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
 				},
-				ValidateFunc: func(iban IBAN) error {
-					return nil
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					return ValidationResult{}, nil
 				},
 			},
 			expectedStatusCode: http.StatusOK,
@@ -90,7 +135,7 @@ func TestController_validate(t *testing.T) {
 			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate", nil),
 			want: fmt.Sprintf(`{"error":"%s","is_valid":false,"iban":null}`, "parsing error"),
 			parser: &mockParser{
-				ParseFunc: func(s string) (IBAN, error) {
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
 					return IBAN{}, errors.New("parsing error")
 				},
 			},
@@ -99,7 +144,360 @@ func TestController_validate(t *testing.T) {
 		{
 			name: "validation error returns 422",
 			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate", nil),
-			want: fmt.Sprintf(`{"error":"%s","is_valid":false,"iban":{"country_code":"NL","check_digits":"22","bban":"555566667777"}}`, "validation error"),
+			want: fmt.Sprintf(`{"error":"%s","is_valid":false,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},`+
+				`"checks":[{"name":"length","status":"passed"},{"name":"iban_checksum","status":"failed","error":"%s"}]}`, "validation error", "validation error"),
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					return ValidationResult{Checks: []Check{
+						{Name: CheckLength, Status: CheckStatusPassed},
+						failedCheck(CheckIBANChecksum, errors.New("validation error")),
+					}}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "validates at as_of date",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate?as_of=2019-12-31", nil),
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"}}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					if !t.Equal(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)) {
+						return IBAN{}, fmt.Errorf("unexpected date: %s", t)
+					}
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					if !t.Equal(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)) {
+						return ValidationResult{Checks: []Check{failedCheck(CheckCountry, fmt.Errorf("unexpected date: %s", t))}}, nil
+					}
+					return ValidationResult{}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid as_of date returns 400",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate?as_of=31.12.2019", nil),
+			want:               `{"error":"invalid as_of date \"31.12.2019\", expected format 2006-01-02","is_valid":false,"iban":null}`,
+			parser:             &mockParser{},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "checksum error returns suggestions",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667778/validate", nil),
+			want: `{"error":"iban checksum validation error: IBAN has the incorrect checksum","is_valid":false,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667778"},"formats":{"electronic":"NL22555566667778","print":"NL22 5555 6666 7778"},` +
+				`"checks":[{"name":"iban_checksum","status":"failed","error":"iban checksum validation error: IBAN has the incorrect checksum"}],` +
+				`"suggestions":[{"iban":"NL22555566667777","kind":"substitution","position":15}]}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667778"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					return ValidationResult{Checks: []Check{
+						failedCheck(CheckIBANChecksum, fmt.Errorf("iban checksum validation error: %w", ErrIncorrectIBANChecksum)),
+					}}, nil
+				},
+				SuggestFunc: func(iban IBAN) []Suggestion {
+					return []Suggestion{{IBAN: "NL22555566667777", Kind: SuggestionSubstitution, Position: 15}}
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "validation error with details",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate", nil),
+			want: `{"error":"bban format validation error: IBAN has the incorrect BBAN format for the specified country","is_valid":false,` +
+				`"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},` +
+				`"error_details":{"code":"incorrect_bban_format","component":"bank_code","offset":4},` +
+				`"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},` +
+				`"checks":[{"name":"bban_format","status":"failed","error":"bban format validation error: IBAN has the incorrect BBAN format for the specified country",` +
+				`"error_details":{"code":"incorrect_bban_format","component":"bank_code","offset":4}}]}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					err := fmt.Errorf("bban format validation error: %w", newValidationError(ErrIncorrectBBANFormat, ComponentBankCode, 4))
+					return ValidationResult{Checks: []Check{failedCheck(CheckBBANFormat, err)}}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "normalizes pasted IBAN",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/%22iban:nl22-5555-6666-7777%22/validate", nil),
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},` +
+				`"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},` +
+				`"normalizations":["removed_quotes","removed_label","removed_separators","upper_cased"]}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					if s != "NL22555566667777" {
+						return IBAN{}, fmt.Errorf("unexpected iban: %s", s)
+					}
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					return ValidationResult{}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "validates at the requested level",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate?level=syntax", nil),
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},` +
+				`"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},` +
+				`"checks":[{"name":"length","status":"passed"},{"name":"iban_checksum","status":"skipped"}]}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					if level != LevelSyntax {
+						return ValidationResult{Checks: []Check{failedCheck(CheckCountry, fmt.Errorf("unexpected level: %s", level))}}, nil
+					}
+					return ValidationResult{Checks: []Check{
+						{Name: CheckLength, Status: CheckStatusPassed},
+						{Name: CheckIBANChecksum, Status: CheckStatusSkipped},
+					}}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "unknown level returns 400",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate?level=everything", nil),
+			want: `{"error":"validation level is unknown: \"everything\", expected one of [syntax checksum national directory]","is_valid":false,"iban":null}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					_, err := ParseLevel(string(level))
+					return ValidationResult{}, err
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "directory level without a bank directory returns 400",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/validate?level=directory", nil),
+			want: `{"error":"validation level requires a bank directory: directory","is_valid":false,"iban":null}`,
+			parser: &mockParser{
+				ParseAtFunc: func(s string, t time.Time) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+				},
+				ValidateLevelFunc: func(iban IBAN, t time.Time, level Level) (ValidationResult, error) {
+					return ValidationResult{}, fmt.Errorf("%w: %s", ErrBankDirectoryRequired, level)
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "strict mode rejects IBAN that is not canonical",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/nl22555566667777/validate?strict=true", nil),
+			want:               `{"error":"IBAN is not in its canonical electronic form: upper_cased","is_valid":false,"iban":null}`,
+			parser:             &mockParser{},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.validate(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+// failedCheck returns the check as ValidateLevel reports it if it failed with err.
+func failedCheck(name string, err error) Check {
+	check := Check{Name: name, Status: CheckStatusFailed, Error: errorString(err), err: err}
+	errors.As(err, &check.ErrorDetails)
+	return check
+}
+
+func TestController_generate(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			r:    httptest.NewRequest(http.MethodPost, "/v1/iban/generate", strings.NewReader(`{"country_code":"de","bank_code":"3704 0044","account_number":"532013000"}`)),
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"DE","check_digits":"89","bban":"370400440532013000","bank_code":"37040044","account_number":"0532013000"},"formats":{"electronic":"DE89370400440532013000","print":"DE89 3704 0044 0532 0130 00","national":"bank code 37040044, account 532013000"}}`,
+			parser: &mockParser{
+				GenerateFunc: func(d NationalDetails) (IBAN, error) {
+					if d != (NationalDetails{CountryCode: "DE", BankCode: "37040044", AccountNumber: "532013000"}) {
+						return IBAN{}, fmt.Errorf("unexpected details: %+v", d)
+					}
+					return IBAN{CountryCode: "DE", CheckDigits: "89", BBAN: "370400440532013000", BankCode: "37040044", AccountNumber: "0532013000"}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "malformed body returns 400",
+			r:                  httptest.NewRequest(http.MethodPost, "/v1/iban/generate", strings.NewReader(`{"country_code":`)),
+			want:               `{"error":"failed to decode request body: unexpected EOF","is_valid":false,"iban":null}`,
+			parser:             &mockParser{},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "generation error returns 422",
+			r:    httptest.NewRequest(http.MethodPost, "/v1/iban/generate", strings.NewReader(`{"country_code":"DE"}`)),
+			want: `{"error":"generation error","is_valid":false,"iban":null}`,
+			parser: &mockParser{
+				GenerateFunc: func(d NationalDetails) (IBAN, error) {
+					return IBAN{}, errors.New("generation error")
+				},
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.generate(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestController_repair(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL00555566667777/repair", nil),
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"}}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "00", BBAN: "555566667777"}, nil
+				},
+				RepairFunc: func(iban IBAN) (IBAN, error) {
+					iban.CheckDigits = "22"
+					return iban, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "parsing error returns 422",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL00555566667777/repair", nil),
+			want: `{"error":"parsing error","is_valid":false,"iban":null}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (IBAN, error) {
+					return IBAN{}, errors.New("parsing error")
+				},
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "repaired IBAN with other faults returns 200",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL00555566667777/repair", nil),
+			want: `{"error":"validation error","is_valid":false,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"}}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (IBAN, error) {
+					return IBAN{CountryCode: "NL", CheckDigits: "00", BBAN: "555566667777"}, nil
+				},
+				RepairFunc: func(iban IBAN) (IBAN, error) {
+					iban.CheckDigits = "22"
+					return iban, errors.New("validation error")
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.repair(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestController_crossCheck(t *testing.T) {
+	ibanParser := func(err error) *mockParser {
+		return &mockParser{
+			ParseFunc: func(s string) (IBAN, error) {
+				return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
+			},
+			ValidateFunc: func(iban IBAN) error {
+				return nil
+			},
+			CrossCheckFunc: func(iban IBAN, b bic.BIC) error {
+				return err
+			},
+		}
+	}
+	bicParser := &mockBICParser{
+		ParseFunc: func(s string) (bic.BIC, error) {
+			if s != "ABNANL2A" {
+				return bic.BIC{}, errors.New("parsing error")
+			}
+			return bic.BIC{InstitutionCode: "ABNA", CountryCode: "NL", LocationCode: "2A"}, nil
+		},
+	}
+
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name:   "success",
+			r:      httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/bic/abnanl2a/validate", nil),
+			parser: ibanParser(nil),
+			want: `{"error":null,"is_valid":true,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},` +
+				`"bic":{"institution_code":"ABNA","country_code":"NL","location_code":"2A","is_test_bic":false}}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "mismatch returns reason",
+			r:      httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/bic/ABNANL2A/validate", nil),
+			parser: ibanParser(fmt.Errorf("%w: expected RABONL2U of Rabobank", ErrBICBankMismatch)),
+			want: `{"error":"BIC belongs to another bank than the one identified by the IBAN: expected RABONL2U of Rabobank","is_valid":false,` +
+				`"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},` +
+				`"bic":{"institution_code":"ABNA","country_code":"NL","location_code":"2A","is_test_bic":false},"mismatch_reason":"bank"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "invalid IBAN is not cross-checked",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/bic/ABNANL2A/validate", nil),
 			parser: &mockParser{
 				ParseFunc: func(s string) (IBAN, error) {
 					return IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}, nil
@@ -108,8 +506,143 @@ func TestController_validate(t *testing.T) {
 					return errors.New("validation error")
 				},
 			},
+			want: `{"error":"validation error","is_valid":false,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"},` +
+				`"bic":{"institution_code":"ABNA","country_code":"NL","location_code":"2A","is_test_bic":false}}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "BIC parsing error returns 422",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/NL22555566667777/bic/ABNA/validate", nil),
+			parser:             ibanParser(nil),
+			want:               `{"error":"invalid bic: parsing error","is_valid":false,"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"}}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "IBAN parsing error returns 422 with the normalizations",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/iban/nl22-5555-6666-777/bic/ABNANL2A/validate", nil),
+			parser: &mockParser{
+				ParseFunc: func(s string) (IBAN, error) {
+					return IBAN{}, errors.New("parsing error")
+				},
+			},
+			want:               `{"error":"parsing error","is_valid":false,"iban":null,"normalizations":["removed_separators","upper_cased"]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, bicParser: bicParser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.crossCheck(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestController_checkDigits(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name:               "success",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/check-digits?country_code=DE&bban=370400440532013000", nil),
+			want:               `{"error":null,"country_code":"DE","bban":"370400440532013000","check_digits":"89","iban":"DE89370400440532013000"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "normalizes the country code and BBAN",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/check-digits?country_code=gb&bban=nwbk%206016%201331%20926819", nil),
+			want:               `{"error":null,"country_code":"GB","bban":"NWBK60161331926819","check_digits":"29","iban":"GB29NWBK60161331926819"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "missing BBAN returns 422",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/check-digits?country_code=DE", nil),
+			want:               `{"error":"BBAN is empty","country_code":"DE","bban":""}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "missing country code returns 422",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/check-digits?bban=370400440532013000", nil),
+			want:               `{"error":"country code is empty","country_code":"","bban":"370400440532013000"}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "malformed BBAN returns 422",
+			r:                  httptest.NewRequest(http.MethodGet, "/v1/iban/check-digits?country_code=DE&bban=3704-0044", nil),
+			want:               fmt.Sprintf(`{"error":%q,"country_code":"DE","bban":"3704-0044"}`, ErrIncorrectIbanFormat.Error()),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: &mockParser{}, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.checkDigits(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestController_extract(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			r:    httptest.NewRequest(http.MethodPost, "/v1/iban/extract", strings.NewReader("pay to NL22 5555 6666 7777 or NL00555566667777")),
+			parser: &mockParser{
+				ExtractFunc: func(text string) []Match {
+					return []Match{
+						{Start: 7, End: 26, Text: "NL22 5555 6666 7777", IBAN: IBAN{CountryCode: "NL", CheckDigits: "22", BBAN: "555566667777"}},
+						{Start: 30, End: 46, Text: "NL00555566667777", IBAN: IBAN{CountryCode: "NL", CheckDigits: "00", BBAN: "555566667777"}, Err: ErrIncorrectIBANChecksum},
+					}
+				},
+			},
+			want: `{"error":null,"matches":[` +
+				`{"start":7,"end":26,"text":"NL22 5555 6666 7777","error":null,"is_valid":true,` +
+				`"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"22","bban":"555566667777"},` +
+				`"formats":{"electronic":"NL22555566667777","print":"NL22 5555 6666 7777"}},` +
+				`{"start":30,"end":46,"text":"NL00555566667777","error":"IBAN has the incorrect checksum","is_valid":false,` +
+				`"iban":{"sepa":false,"eea":false,"country_code":"NL","check_digits":"00","bban":"555566667777"},` +
+				`"formats":{"electronic":"NL00555566667777","print":"NL00 5555 6666 7777"}}]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "no IBANs found",
+			r:    httptest.NewRequest(http.MethodPost, "/v1/iban/extract", strings.NewReader("nothing to see here")),
+			parser: &mockParser{
+				ExtractFunc: func(text string) []Match {
+					return nil
+				},
+			},
+			want:               `{"error":null,"matches":[]}`,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:               "body too large returns 400",
+			r:                  httptest.NewRequest(http.MethodPost, "/v1/iban/extract", strings.NewReader(strings.Repeat("a", maxExtractBodySize+1))),
+			parser:             &mockParser{},
+			want:               `{"error":"failed to read request body: http: request body too large","matches":null}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -119,7 +652,8 @@ func TestController_validate(t *testing.T) {
 			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
 
 			w := httptest.NewRecorder()
-			ctrl.validate(w, tt.r)
+			ctrl.extract(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
 			require.JSONEq(t, tt.want, w.Body.String())
 		})
 	}
@@ -3,9 +3,9 @@ package iban
 import (
 	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
-	"unicode"
+	"time"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/mod97"
 )
 
 var (
@@ -13,42 +13,108 @@ var (
 	ErrIncorrectBBANFormat   = errors.New("IBAN has the incorrect BBAN format for the specified country")
 	ErrIncorrectBBANChecksum = errors.New("IBAN has the incorrect BBAN checksum for the specified country")
 	ErrIncorrectIBANChecksum = errors.New("IBAN has the incorrect checksum")
-)
 
-var (
-	countryValidators = map[string]countryValidator{
-		"AL": {CountryCode: "AL", Length: 28, BBANRegex: regexp.MustCompile(`^[0-9]{8}[0-9A-Z]{16}$`)},
-		"AT": {CountryCode: "AT", Length: 20, BBANRegex: regexp.MustCompile(`^\d{16}$`)},
-		"BA": {
-			CountryCode: "BA",
-			Length:      20,
-			BBANRegex:   regexp.MustCompile(`^\d{16}$`),
-			BBANChecksumFunc: func(bban string) bool {
-				return true
-
-				// return 98-mod97(bban[:14]) != 1
-				// XXX: Bosnian BBAN supposedly uses a non-variant of ISO7064 MOD-97-10 to validate the BBAN check digits
-				//      however, the check-digits cannot be computed successfully, neither using the entire BBAN,
-				//      nor excluding the check-digits, nor using only the account number,
-				//      both with the standard-mod97 and with the 98-r complement.
-				//      I cannot find any reliable information in english on the exact specs
-				//      for Bosnian BBAN checksum computation, so I'll leave it for now.
-
-			},
-		},
-		"BR": {CountryCode: "BR", Length: 29, BBANRegex: regexp.MustCompile(`^\d{23}[A-Z]{1}[A-Z\d]{1}$`)},
-		"CH": {CountryCode: "CH", Length: 21, BBANRegex: regexp.MustCompile(`^\d{17}$`)},
-		"DE": {CountryCode: "DE", Length: 22, BBANRegex: regexp.MustCompile(`^\d{18}$`)},
-		"FR": {CountryCode: "FR", Length: 27, BBANRegex: regexp.MustCompile(`^\d{10}[A-Z0-9]{11}\d{2}$`)},
-		"GB": {CountryCode: "GB", Length: 22, BBANRegex: regexp.MustCompile(`^[A-Z]{4}\d{14}$`)},
-	}
+	ErrUnknownBankCode          = errors.New("IBAN has a bank code that is unknown in the specified country")
+	ErrIncorrectAccountNumber   = errors.New("IBAN has an account number that is invalid for its bank")
+	ErrIncorrectSortCodeAccount = errors.New("IBAN has a sort code and account number that fail the UK modulus check")
+	// ErrAccountCheckNotSupported means the account number could not be checked, e.g. because the bank's check method
+	// is not implemented. It does not render the IBAN invalid.
+	ErrAccountCheckNotSupported = errors.New("IBAN has an account number that cannot be checked for its bank")
 )
 
 type countryValidator struct {
-	CountryCode      string
-	Length           int
-	BBANRegex        *regexp.Regexp
-	BBANChecksumFunc func(string) bool
+	CountryCode string
+	// GoverningCountryCode is the country whose IBAN format a territory follows, empty for countries.
+	GoverningCountryCode string
+	// Experimental is set for countries whose IBAN-like format is not part of the SWIFT IBAN Registry.
+	Experimental bool
+
+	// EffectiveFrom and EffectiveUntil are the first and last day the rules are in effect, zero if unbounded.
+	EffectiveFrom  time.Time
+	EffectiveUntil time.Time
+	// Revisions are the country's earlier rules, latest first.
+	Revisions []countryValidator
+
+	Length        int
+	BBANStructure bbanStructure
+	BBANChecksum  bbanChecksum
+
+	// AccountValidator optionally validates the BBAN against national bank data, e.g. a bank directory.
+	AccountValidator func(bban string) error
+
+	// positions of the BBAN's components, zero values for components the country does not have
+	BankCode            bbanRange
+	BranchCode          bbanRange
+	AccountNumber       bbanRange
+	NationalCheckDigits bbanRange
+}
+
+// bbanRange is a 0-based, half-open range of characters within a BBAN.
+type bbanRange struct {
+	Start int
+	End   int
+}
+
+// extract returns the characters of the bban within the range, or an empty string if the range is unset.
+func (r bbanRange) extract(bban string) string {
+	if r.End == 0 || r.End > len(bban) {
+		return ""
+	}
+
+	return bban[r.Start:r.End]
+}
+
+// EffectiveAt reports whether the rules are in effect on the day of t.
+func (c countryValidator) EffectiveAt(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	return (c.EffectiveFrom.IsZero() || !day.Before(c.EffectiveFrom)) &&
+		(c.EffectiveUntil.IsZero() || !day.After(c.EffectiveUntil))
+}
+
+// At returns the country's rules that are in effect on the day of t, if any.
+func (c countryValidator) At(t time.Time) (countryValidator, bool) {
+	if c.EffectiveAt(t) {
+		return c, true
+	}
+
+	for _, revision := range c.Revisions {
+		if revision.EffectiveAt(t) {
+			// the country's settings apply to all of its rules
+			revision.CountryCode = c.CountryCode
+			revision.GoverningCountryCode = c.GoverningCountryCode
+			revision.Experimental = c.Experimental
+			revision.AccountValidator = c.AccountValidator
+			return revision, true
+		}
+	}
+
+	return countryValidator{}, false
+}
+
+// Annotate fills the information the country's rules provide about the iban, i.e. its BBAN components,
+// governing country, registry status, SEPA and EEA membership and currency.
+func (c countryValidator) Annotate(iban IBAN) IBAN {
+	iban.GoverningCountryCode = c.GoverningCountryCode
+	if c.Experimental {
+		iban.RegistryStatus = RegistryStatusExperimental
+	}
+
+	return c.SplitBBAN(annotateSEPA(iban))
+}
+
+// SplitBBAN fills the BBAN components of the iban, if its BBAN has the length the country expects.
+func (c countryValidator) SplitBBAN(iban IBAN) IBAN {
+	if len(iban.BBAN) != c.BBANStructure.MaxLength() {
+		return iban
+	}
+
+	iban.BankCode = c.BankCode.extract(iban.BBAN)
+	iban.BranchCode = c.BranchCode.extract(iban.BBAN)
+	iban.AccountNumber = c.AccountNumber.extract(iban.BBAN)
+	iban.NationalCheckDigits = c.NationalCheckDigits.extract(iban.BBAN)
+
+	return iban
 }
 
 func (c countryValidator) ValidateIbanLength(iban IBAN) error {
@@ -60,16 +126,20 @@ func (c countryValidator) ValidateIbanLength(iban IBAN) error {
 }
 
 func (c countryValidator) ValidateBbanFormat(iban IBAN) error {
-	if !c.BBANRegex.MatchString(iban.BBAN) {
-		return ErrIncorrectBBANFormat
+	if !c.BBANStructure.Match(iban.BBAN) {
+		return fmt.Errorf("%w: expected %s", ErrIncorrectBBANFormat, c.BBANStructure.Describe())
 	}
 
 	return nil
 }
 
 func (c countryValidator) ValidateBbanChecksum(iban IBAN) error {
-	if c.BBANChecksumFunc != nil && !c.BBANChecksumFunc(iban.BBAN) {
-		return ErrIncorrectBBANChecksum
+	if c.BBANChecksum.Func != nil && !c.BBANChecksum.Func(iban.BBAN) {
+		return fmt.Errorf("%w: %s check failed", ErrIncorrectBBANChecksum, c.BBANChecksum.Name)
+	}
+
+	if c.AccountValidator != nil {
+		return c.AccountValidator(iban.BBAN)
 	}
 
 	return nil
@@ -79,21 +149,12 @@ func (c countryValidator) ValidateIbanChecksum(iban IBAN) error {
 	// Ref: https://en.wikipedia.org/wiki/International_Bank_Account_Number#Validating_the_IBAN
 	transposedIban := fmt.Sprintf("%s%s%s", iban.BBAN, iban.CountryCode, iban.CheckDigits)
 
-	str := ""
-	for _, r := range transposedIban {
-		if unicode.IsDigit(r) {
-			str += string(r)
-			continue
-		}
-
-		digits, err := convertLetterToInt(r)
-		if err != nil {
-			return fmt.Errorf("failed to convert IBAN into numeric format: %w", err)
-		}
-		str += strconv.Itoa(digits)
+	valid, err := mod97.Valid(transposedIban)
+	if err != nil {
+		return fmt.Errorf("failed to convert IBAN into numeric format: %w", err)
 	}
 
-	if mod97(str) != 1 {
+	if !valid {
 		return ErrIncorrectIBANChecksum
 	}
 
@@ -1,7 +1,6 @@
 package iban
 
 import (
-	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -56,21 +55,21 @@ func Test_countryValidator_ValidateIbanLength(t *testing.T) {
 
 func Test_countryValidator_ValidateBbanFormat(t *testing.T) {
 	tests := []struct {
-		name      string
-		BBANRegex *regexp.Regexp
-		iban      IBAN
-		wantErr   error
+		name          string
+		BBANStructure bbanStructure
+		iban          IBAN
+		wantErr       error
 	}{
 		{
-			name:      "valid BBAN",
-			BBANRegex: regexp.MustCompile(`^\d{10}[A-Z0-9]{11}\d{2}$`),
-			iban:      IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
+			name:          "valid BBAN",
+			BBANStructure: mustParseBBANStructure("10!n11!c2!n"),
+			iban:          IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
 		},
 		{
-			name:      "invalid BBAN",
-			BBANRegex: regexp.MustCompile(`^\d{10}[A-Z0-9]{11}\d{2}$`),
-			iban:      IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF0"}, // missing last digit
-			wantErr:   ErrIncorrectBBANFormat,
+			name:          "invalid BBAN",
+			BBANStructure: mustParseBBANStructure("10!n11!c2!n"),
+			iban:          IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF0"}, // missing last digit
+			wantErr:       ErrIncorrectBBANFormat,
 		},
 	}
 	for _, tt := range tests {
@@ -78,7 +77,7 @@ func Test_countryValidator_ValidateBbanFormat(t *testing.T) {
 			tt := tt
 			t.Parallel()
 
-			c := countryValidator{BBANRegex: tt.BBANRegex}
+			c := countryValidator{BBANStructure: tt.BBANStructure}
 			err := c.ValidateBbanFormat(tt.iban)
 			require.ErrorIs(t, err, tt.wantErr)
 		})
@@ -87,21 +86,27 @@ func Test_countryValidator_ValidateBbanFormat(t *testing.T) {
 
 func Test_countryValidator_ValidateBbanChecksum(t *testing.T) {
 	tests := []struct {
-		name             string
-		BBANChecksumFunc func(string) bool
-		iban             IBAN
-		wantErr          error
+		name         string
+		BBANChecksum bbanChecksum
+		iban         IBAN
+		wantErr      error
+		wantErrStr   string
 	}{
 		{
-			name:             "valid BBAN",
-			BBANChecksumFunc: func(bban string) bool { return true },
-			iban:             IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
+			name:         "valid BBAN",
+			BBANChecksum: bbanChecksum{Name: "always valid", Func: func(bban string) bool { return true }},
+			iban:         IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
 		},
 		{
-			name:             "invalid BBAN",
-			BBANChecksumFunc: func(bban string) bool { return false },
-			iban:             IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
-			wantErr:          ErrIncorrectBBANChecksum,
+			name:         "invalid BBAN",
+			BBANChecksum: bbanChecksum{Name: "never valid", Func: func(bban string) bool { return false }},
+			iban:         IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
+			wantErr:      ErrIncorrectBBANChecksum,
+			wantErrStr:   ErrIncorrectBBANChecksum.Error() + ": never valid check failed",
+		},
+		{
+			name: "country without national checksum",
+			iban: IBAN{CountryCode: "FR", CheckDigits: "12", BBAN: "1234567890ABC12345DEF01"},
 		},
 	}
 	for _, tt := range tests {
@@ -109,9 +114,12 @@ func Test_countryValidator_ValidateBbanChecksum(t *testing.T) {
 			tt := tt
 			t.Parallel()
 
-			c := countryValidator{BBANChecksumFunc: tt.BBANChecksumFunc}
+			c := countryValidator{BBANChecksum: tt.BBANChecksum}
 			err := c.ValidateBbanChecksum(tt.iban)
 			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErrStr != "" {
+				require.EqualError(t, err, tt.wantErrStr)
+			}
 		})
 	}
 }
@@ -153,3 +161,116 @@ func Test_countryValidator_ValidateIbanChecksum(t *testing.T) {
 		})
 	}
 }
+
+// registryExampleIBANs contains the example IBAN of every country listed in the SWIFT IBAN Registry.
+var registryExampleIBANs = map[string]string{
+	"AD": "AD1200012030200359100100",
+	"AE": "AE070331234567890123456",
+	"AL": "AL47212110090000000235698741",
+	"AT": "AT611904300234573201",
+	"AZ": "AZ21NABZ00000000137010001944",
+	"BA": "BA391290079401028494",
+	"BE": "BE68539007547034",
+	"BG": "BG80BNBG96611020345678",
+	"BH": "BH67BMAG00001299123456",
+	"BI": "BI4210000100010000332045181",
+	"BR": "BR1800360305000010009795493C1",
+	"BY": "BY13NBRB3600900000002Z00AB00",
+	"CH": "CH9300762011623852957",
+	"CR": "CR05015202001026284066",
+	"CY": "CY17002001280000001200527600",
+	"CZ": "CZ6508000000192000145399",
+	"DE": "DE89370400440532013000",
+	"DJ": "DJ2100010000000154000100186",
+	"DK": "DK5000400440116243",
+	"DO": "DO28BAGR00000001212453611324",
+	"EE": "EE382200221020145685",
+	"EG": "EG380019000500000000263180002",
+	"ES": "ES9121000418450200051332",
+	"FI": "FI2112345600000785",
+	"FK": "FK88SC123456789012",
+	"FO": "FO6264600001631634",
+	"FR": "FR1420041010050500013M02606",
+	"GB": "GB29NWBK60161331926819",
+	"GE": "GE29NB0000000101904917",
+	"GI": "GI75NWBK000000007099453",
+	"GL": "GL8964710001000206",
+	"GR": "GR1601101250000000012300695",
+	"GT": "GT82TRAJ01020000001210029690",
+	"HN": "HN88CABF00000000000250005469",
+	"HR": "HR1210010051863000160",
+	"HU": "HU42117730161111101800000000",
+	"IE": "IE29AIBK93115212345678",
+	"IL": "IL620108000000099999999",
+	"IQ": "IQ98NBIQ850123456789012",
+	"IS": "IS140159260076545510730339",
+	"IT": "IT60X0542811101000000123456",
+	"JO": "JO94CBJO0010000000000131000302",
+	"KW": "KW81CBKU0000000000001234560101",
+	"KZ": "KZ86125KZT5004100100",
+	"LB": "LB62099900000001001901229114",
+	"LC": "LC55HEMM000100010012001200023015",
+	"LI": "LI21088100002324013AA",
+	"LT": "LT121000011101001000",
+	"LU": "LU280019400644750000",
+	"LV": "LV80BANK0000435195001",
+	"LY": "LY83002048000020100120361",
+	"MC": "MC5811222000010123456789030",
+	"MD": "MD24AG000225100013104168",
+	"ME": "ME25505000012345678951",
+	"MK": "MK07250120000058984",
+	"MN": "MN121234123456789123",
+	"MR": "MR1300020001010000123456753",
+	"MT": "MT84MALT011000012345MTLCAST001S",
+	"MU": "MU17BOMM0101101030300200000MUR",
+	"NI": "NI45BAPR00000013000003558124",
+	"NL": "NL91ABNA0417164300",
+	"NO": "NO9386011117947",
+	"OM": "OM810180000001299123456",
+	"PK": "PK36SCBL0000001123456702",
+	"PL": "PL61109010140000071219812874",
+	"PS": "PS92PALS000000000400123456702",
+	"PT": "PT50000201231234567890154",
+	"QA": "QA58DOHB00001234567890ABCDEFG",
+	"RO": "RO49AAAA1B31007593840000",
+	"RS": "RS35260005601001611379",
+	"RU": "RU0304452522540817810538091310419",
+	"SA": "SA0380000000608010167519",
+	"SC": "SC18SSCB11010000000000001497USD",
+	"SD": "SD2129010501234001",
+	"SE": "SE4550000000058398257466",
+	"SI": "SI56263300012039086",
+	"SK": "SK3112000000198742637541",
+	"SM": "SM86U0322509800000000270100",
+	"SO": "SO211000001001000100141",
+	"ST": "ST32000200010192194210112",
+	"SV": "SV62CENR00000000000000700025",
+	"TL": "TL380080012345678910157",
+	"TN": "TN5910006035183598478831",
+	"TR": "TR330006100519786457841326",
+	"UA": "UA213223130000026007233566001",
+	"VA": "VA59001123000012345678",
+	"VG": "VG96VPVG0000012345678901",
+	"XK": "XK051212012345678906",
+	"YE": "YE15CBYE0001018861234567891234",
+}
+
+func Test_countryValidators_RegistryExamples(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+	require.Len(t, svc.validators, len(registryExampleIBANs), "every country needs an example IBAN")
+
+	for countryCode, ibanStr := range registryExampleIBANs {
+		t.Run(countryCode, func(t *testing.T) {
+			countryCode, ibanStr := countryCode, ibanStr
+			t.Parallel()
+
+			require.Contains(t, svc.validators, countryCode)
+
+			iban, err := svc.Parse(ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, countryCode, iban.CountryCode)
+			require.NoError(t, svc.Validate(iban))
+		})
+	}
+}
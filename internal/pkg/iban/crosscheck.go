@@ -0,0 +1,51 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
+)
+
+// Reasons why a BIC does not match an IBAN, to be shown to the user.
+const (
+	MismatchReasonCountry = "country" // the BIC belongs to a bank in another country
+	MismatchReasonBank    = "bank"    // the BIC belongs to another bank than the one identified in the BBAN
+)
+
+var (
+	ErrBICCountryMismatch = errors.New("BIC belongs to a bank in another country than the IBAN")
+	ErrBICBankMismatch    = errors.New("BIC belongs to another bank than the one identified by the IBAN")
+)
+
+// CrossCheck checks that the BIC belongs to the bank of the IBAN. The BIC's country must be the IBAN's country,
+// or the governing country for IBANs of territories. If the bank directory knows the IBAN's bank, the BIC must
+// also identify the same institution and location, i.e. its first 8 characters must match the bank's BIC.
+func (svc *Service) CrossCheck(i IBAN, b bic.BIC) error {
+	if b.CountryCode != i.CountryCode && b.CountryCode != i.GoverningCountryCode {
+		return fmt.Errorf("%w: BIC country code is %s, IBAN country code is %s", ErrBICCountryMismatch, b.CountryCode, i.CountryCode)
+	}
+
+	bank := svc.lookupBank(i)
+	if bank == nil || len(bank.BIC) < 8 {
+		return nil // the bank is unknown, so only the country can be checked
+	}
+
+	if bic8 := b.InstitutionCode + b.CountryCode + b.LocationCode; bic8 != bank.BIC[:8] {
+		return fmt.Errorf("%w: expected %s of %s", ErrBICBankMismatch, bank.BIC, bank.Name)
+	}
+
+	return nil
+}
+
+// mismatchReason returns the reason of a CrossCheck error, or an empty string if err is not a mismatch.
+func mismatchReason(err error) string {
+	switch {
+	case errors.Is(err, ErrBICCountryMismatch):
+		return MismatchReasonCountry
+	case errors.Is(err, ErrBICBankMismatch):
+		return MismatchReasonBank
+	default:
+		return ""
+	}
+}
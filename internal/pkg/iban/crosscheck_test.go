@@ -0,0 +1,87 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
+)
+
+func TestService_CrossCheck(t *testing.T) {
+	dir := bankdir.NewDirectory([]bankdir.Bank{
+		{CountryCode: "GB", BankCode: "NWBK", Name: "National Westminster Bank", BIC: "NWBKGB2L"},
+		{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank", BIC: "COBADEFFXXX"},
+		{CountryCode: "FR", BankCode: "20041", Name: "La Banque Postale"},
+	})
+
+	tests := []struct {
+		name       string
+		ibanStr    string
+		bic        bic.BIC
+		wantErr    error
+		wantReason string
+	}{
+		{
+			name:    "matching bank",
+			ibanStr: "DE89370400440532013000",
+			bic:     bic.BIC{InstitutionCode: "COBA", CountryCode: "DE", LocationCode: "FF"},
+		},
+		{
+			name:    "matching bank with branch code",
+			ibanStr: "GB29NWBK60161331926819",
+			bic:     bic.BIC{InstitutionCode: "NWBK", CountryCode: "GB", LocationCode: "2L", BranchCode: "XXX"},
+		},
+		{
+			name:       "other bank",
+			ibanStr:    "DE89370400440532013000",
+			bic:        bic.BIC{InstitutionCode: "DEUT", CountryCode: "DE", LocationCode: "FF"},
+			wantErr:    ErrBICBankMismatch,
+			wantReason: MismatchReasonBank,
+		},
+		{
+			name:       "other country",
+			ibanStr:    "DE89370400440532013000",
+			bic:        bic.BIC{InstitutionCode: "COBA", CountryCode: "FR", LocationCode: "PP"},
+			wantErr:    ErrBICCountryMismatch,
+			wantReason: MismatchReasonCountry,
+		},
+		{
+			name:    "unknown bank only checks country",
+			ibanStr: "NL91ABNA0417164300",
+			bic:     bic.BIC{InstitutionCode: "RABO", CountryCode: "NL", LocationCode: "2U"},
+		},
+		{
+			name:    "bank without BIC only checks country",
+			ibanStr: "FR1420041010050500013M02606",
+			bic:     bic.BIC{InstitutionCode: "PSST", CountryCode: "FR", LocationCode: "PP"},
+		},
+		{
+			name:    "territory with BIC of governing country",
+			ibanStr: "JE90NWBK60161331926819",
+			bic:     bic.BIC{InstitutionCode: "NWBK", CountryCode: "GB", LocationCode: "2L"},
+		},
+		{
+			name:    "territory with BIC of its own country",
+			ibanStr: "GF4120041010050500013M02606",
+			bic:     bic.BIC{InstitutionCode: "PSST", CountryCode: "GF", LocationCode: "PP"},
+		},
+	}
+
+	svc, err := NewService(WithBankDirectory(dir))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+
+			err = svc.CrossCheck(iban, tt.bic)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantReason, mismatchReason(err))
+		})
+	}
+}
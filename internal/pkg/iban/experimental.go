@@ -0,0 +1,46 @@
+package iban
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+)
+
+// RegistryStatusExperimental flags IBANs of countries that use an IBAN-like format which is not part of the
+// SWIFT IBAN Registry.
+const RegistryStatusExperimental = "experimental"
+
+// experimentalRegistry lists countries that publish IBAN-like formats outside of the SWIFT IBAN Registry,
+// in the same format as the registry export.
+//
+//go:embed data/experimental.json
+var experimentalRegistry []byte
+
+// WithExperimentalCountries enables the validation of IBANs of countries that are not part of the SWIFT IBAN Registry,
+// e.g. Algeria, Angola, Cameroon, Iran and Madagascar. Their IBANs are flagged as experimental.
+// Countries listed in the registry are always validated by the registry's rules.
+func WithExperimentalCountries() Option {
+	return func(svc *Service) error {
+		svc.experimental = true
+		return nil
+	}
+}
+
+// addExperimentalCountries adds the validators of the experimental countries that are missing in the registry.
+func (svc *Service) addExperimentalCountries() error {
+	validators, err := loadRegistry(bytes.NewReader(experimentalRegistry))
+	if err != nil {
+		return fmt.Errorf("failed to load experimental countries: %w", err)
+	}
+
+	for countryCode, validator := range validators {
+		if _, ok := svc.validators[countryCode]; ok {
+			continue
+		}
+
+		validator.Experimental = true
+		svc.validators[countryCode] = validator
+	}
+
+	return nil
+}
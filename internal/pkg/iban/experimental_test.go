@@ -0,0 +1,115 @@
+package iban
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Validate_ExperimentalCountries(t *testing.T) {
+	tests := []struct {
+		name               string
+		ibanStr            string
+		wantRegistryStatus string
+		wantErr            error
+	}{
+		{name: "Algeria", ibanStr: "DZ580002100001113000000570", wantRegistryStatus: RegistryStatusExperimental},
+		{name: "Cameroon", ibanStr: "CM2110003001000500000605306", wantRegistryStatus: RegistryStatusExperimental},
+		{name: "registry country", ibanStr: "FR1420041010050500013M02606"},
+		{
+			name:               "national checksum",
+			ibanStr:            "MG1900005030010101914016057",
+			wantRegistryStatus: RegistryStatusExperimental,
+			wantErr:            ErrIncorrectBBANChecksum,
+		},
+	}
+
+	svc, err := NewService(WithExperimentalCountries())
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRegistryStatus, iban.RegistryStatus)
+			require.ErrorIs(t, svc.Validate(iban), tt.wantErr)
+		})
+	}
+}
+
+func TestService_Validate_ExperimentalCountriesDisabledByDefault(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	iban, err := svc.Parse("DZ580002100001113000000570")
+	require.NoError(t, err)
+	require.Empty(t, iban.RegistryStatus)
+	require.ErrorIs(t, svc.Validate(iban), ErrCountryCodeNotSupported)
+}
+
+func TestNewService_WithExperimentalCountries(t *testing.T) {
+	// experimental countries are added even if the registry is replaced by a later option,
+	// but never override a country of the registry
+	pinned := `{"countries": [{"country_code": "DZ", "iban_length": 26, "bban_structure": "22!n"}]}`
+
+	svc, err := NewService(WithExperimentalCountries(), WithRegistry(strings.NewReader(pinned)))
+	require.NoError(t, err)
+	require.False(t, svc.validators["DZ"].Experimental)
+	require.True(t, svc.validators["AO"].Experimental)
+}
+
+func Test_experimentalRegistry_Examples(t *testing.T) {
+	validators, err := loadRegistry(bytes.NewReader(experimentalRegistry))
+	require.NoError(t, err)
+
+	svc, err := NewService(WithExperimentalCountries())
+	require.NoError(t, err)
+
+	for countryCode := range validators {
+		countryCode := countryCode
+		t.Run(countryCode, func(t *testing.T) {
+			t.Parallel()
+
+			_, isRegistryCountry := registryExampleIBANs[countryCode]
+			require.False(t, isRegistryCountry, "experimental country is part of the registry")
+
+			ibanStr, ok := experimentalExampleIBANs[countryCode]
+			require.True(t, ok, "every experimental country needs an example IBAN")
+
+			iban, err := svc.Parse(ibanStr)
+			require.NoError(t, err)
+			require.NoError(t, svc.Validate(iban))
+			require.Equal(t, RegistryStatusExperimental, iban.RegistryStatus)
+		})
+	}
+}
+
+var experimentalExampleIBANs = map[string]string{
+	"AO": "AO06004400006729503010102",
+	"BF": "BF42BF0840101300463574000390",
+	"BJ": "BJ66BJ0610100100144390000769",
+	"CF": "CF4220001000010120069700160",
+	"CG": "CG3930011000101013451300019",
+	"CI": "CI93CI0080111301134291200589",
+	"CM": "CM2110003001000500000605306",
+	"CV": "CV64000300004547069110176",
+	"DZ": "DZ580002100001113000000570",
+	"GA": "GA2140021010032001890020126",
+	"GQ": "GQ7050002001003715228190196",
+	"GW": "GW04GW1430010181800637601",
+	"IR": "IR580540105180021273113007",
+	"KM": "KM4600005000010010904400137",
+	"MA": "MA64011519000001205000534921",
+	"MG": "MG4600005030010101914016056",
+	"ML": "ML03D00890170001002120000447",
+	"MZ": "MZ59000301080016367102371",
+	"NE": "NE58NE0380100100130305000268",
+	"SN": "SN08SN0100152000048500003035",
+	"TD": "TD8960002000010271091600153",
+	"TG": "TG53TG0090604310346500400070",
+}
@@ -0,0 +1,118 @@
+package iban
+
+import "strings"
+
+// maxSeparatorLength is the longest run of separators between two characters of an IBAN found in text,
+// e.g. a line break followed by indentation.
+const maxSeparatorLength = 16
+
+// Match is an IBAN found in text.
+type Match struct {
+	// Start and End are the byte offsets of the IBAN's first character and of the byte after its last character.
+	Start int
+	End   int
+	// Text is the IBAN as written in the text, including separators, e.g. "DE89 3704 0044\n0532 0130 00".
+	Text string
+	// IBAN is the normalized IBAN.
+	IBAN IBAN
+	// Err is the result of validating the IBAN, nil if it is valid.
+	Err error
+}
+
+// Extract finds the IBANs in free text, e.g. invoices, emails or OCR output, and validates them.
+// IBANs may be written in their electronic or print format and be split by single spaces, dashes or a line break.
+// To keep false positives low, only candidates of a supported country that start and end at word boundaries and
+// have the country's length and BBAN format are reported, so the IBANs found may still fail e.g. the checksum.
+func (svc *Service) Extract(text string) []Match {
+	var matches []Match
+	for start := 0; start+4 <= len(text); start++ {
+		if start > 0 && isAlphanumeric(text[start-1]) {
+			continue
+		}
+
+		match, ok := svc.matchAt(text, start)
+		if !ok {
+			continue
+		}
+
+		matches = append(matches, match)
+		start = match.End - 1
+	}
+
+	return matches
+}
+
+// matchAt returns the IBAN starting at the start offset of the text, if there is one.
+func (svc *Service) matchAt(text string, start int) (Match, bool) {
+	if !isUpper(text[start]) || !isUpper(text[start+1]) || !isDigit(text[start+2]) || !isDigit(text[start+3]) {
+		return Match{}, false
+	}
+
+	validator, ok := svc.validator(text[start : start+2])
+	if !ok {
+		return Match{}, false
+	}
+
+	var b strings.Builder
+	pos := start
+	for b.Len() < validator.Length {
+		if pos >= len(text) {
+			return Match{}, false
+		}
+
+		if b.Len() > 0 {
+			pos = skipSeparators(text, pos)
+		}
+		if pos >= len(text) || !isAlphanumeric(text[pos]) {
+			return Match{}, false
+		}
+
+		b.WriteByte(text[pos])
+		pos++
+	}
+
+	// the IBAN must not continue right after the expected length
+	if pos < len(text) && isAlphanumeric(text[pos]) {
+		return Match{}, false
+	}
+
+	iban, err := svc.Parse(b.String())
+	if err != nil || validator.ValidateBbanFormat(iban) != nil {
+		return Match{}, false
+	}
+
+	return Match{Start: start, End: pos, Text: text[start:pos], IBAN: iban, Err: svc.Validate(iban)}, true
+}
+
+// skipSeparators returns the offset after the separators at pos, i.e. a single space, tab or dash, or a line break
+// followed by indentation. pos is returned unchanged if there are other separators, e.g. two line breaks.
+func skipSeparators(text string, pos int) int {
+	end, lineBreaks := pos, 0
+	for end < len(text) && end-pos <= maxSeparatorLength {
+		switch text[end] {
+		case '\n':
+			lineBreaks++
+		case ' ', '\t', '\r', '-':
+		default:
+			if lineBreaks > 1 || (lineBreaks == 0 && end-pos > 1) {
+				return pos
+			}
+			return end
+		}
+		end++
+	}
+
+	return pos
+}
+
+func isUpper(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isAlphanumeric(b byte) bool {
+	return isUpper(b) || isDigit(b) || b >= 'a' && b <= 'z'
+}
@@ -0,0 +1,89 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Extract(t *testing.T) {
+	type match struct {
+		start   int
+		end     int
+		iban    string
+		wantErr error
+	}
+	tests := []struct {
+		name string
+		text string
+		want []match
+	}{
+		{
+			name: "electronic format in prose",
+			text: "Please transfer the amount to DE89370400440532013000 within 14 days.",
+			want: []match{{start: 30, end: 52, iban: "DE89370400440532013000"}},
+		},
+		{
+			name: "print format with label",
+			text: "IBAN: GB29 NWBK 6016 1331 9268 19, BIC: NWBKGB2L",
+			want: []match{{start: 6, end: 33, iban: "GB29NWBK60161331926819"}},
+		},
+		{
+			name: "split across a line break",
+			text: "Account: DE89 3704 0044\n         0532 0130 00\nThank you",
+			want: []match{{start: 9, end: 45, iban: "DE89370400440532013000"}},
+		},
+		{
+			name: "several IBANs, one with an incorrect checksum",
+			text: "from NL91ABNA0417164300 to\r\nBE68-5390-0754-7035.",
+			want: []match{
+				{start: 5, end: 23, iban: "NL91ABNA0417164300"},
+				{start: 28, end: 47, iban: "BE68539007547035", wantErr: ErrIncorrectIBANChecksum},
+			},
+		},
+		{
+			name: "no IBAN",
+			text: "Invoice DE-2024-0001, order 12345678901234567890, VAT ID DE123456789.",
+		},
+		{
+			name: "too long",
+			text: "DE893704004405320130001",
+		},
+		{
+			name: "not at a word boundary",
+			text: "XDE89370400440532013000",
+		},
+		{
+			name: "wrong BBAN format",
+			text: "GB29 1234 6016 1331 9268 19",
+		},
+		{
+			name: "separated by a blank line",
+			text: "DE89 3704 0044\n\n0532 0130 00",
+		},
+		{
+			name: "unsupported country",
+			text: "XX89370400440532013000",
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got := svc.Extract(tt.text)
+			require.Len(t, got, len(tt.want))
+			for i, want := range tt.want {
+				require.Equal(t, want.start, got[i].Start)
+				require.Equal(t, want.end, got[i].End)
+				require.Equal(t, tt.text[want.start:want.end], got[i].Text)
+				require.Equal(t, want.iban, got[i].IBAN.String())
+				require.ErrorIs(t, got[i].Err, want.wantErr)
+			}
+		})
+	}
+}
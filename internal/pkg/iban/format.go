@@ -0,0 +1,106 @@
+package iban
+
+import "strings"
+
+// swagger:model
+type Formats struct {
+	// Electronic is the compact form without separators, e.g. "GB29NWBK60161331926819".
+	Electronic string `json:"electronic"`
+	// Print is the form in groups of four characters, e.g. "GB29 NWBK 6016 1331 9268 19".
+	Print string `json:"print"`
+	// National is the form of the domestic account details, e.g. "sort code 60-16-13, account 31926819",
+	// only set if the BBAN components are known.
+	National string `json:"national,omitempty"`
+}
+
+// nationalFormats render the BBAN components of an iban the way they are written within a country.
+var nationalFormats = map[string]func(IBAN) string{
+	"BE": func(i IBAN) string { return i.BankCode + "-" + i.AccountNumber + "-" + i.NationalCheckDigits },
+	"DE": func(i IBAN) string {
+		return "bank code " + i.BankCode + ", account " + strings.TrimLeft(i.AccountNumber, "0")
+	},
+	"ES": func(i IBAN) string {
+		return "bank " + i.BankCode + ", branch " + i.BranchCode + ", check digits " + i.NationalCheckDigits + ", account " + i.AccountNumber
+	},
+	"FR": func(i IBAN) string {
+		return "bank " + i.BankCode + ", branch " + i.BranchCode + ", account " + i.AccountNumber + ", key " + i.NationalCheckDigits
+	},
+	"GB": sortCodeFormat,
+	"IE": sortCodeFormat,
+	"IT": func(i IBAN) string {
+		return "CIN " + i.NationalCheckDigits + ", ABI " + i.BankCode + ", CAB " + i.BranchCode + ", account " + i.AccountNumber
+	},
+}
+
+// sortCodeFormat renders the sort code in pairs of digits, e.g. "sort code 60-16-13, account 31926819".
+func sortCodeFormat(i IBAN) string {
+	sortCode := i.BranchCode
+	if len(sortCode) == 6 {
+		sortCode = sortCode[:2] + "-" + sortCode[2:4] + "-" + sortCode[4:]
+	}
+
+	return "sort code " + sortCode + ", account " + i.AccountNumber
+}
+
+// FormatElectronic renders the iban in its electronic form, i.e. without any separators.
+func (i IBAN) FormatElectronic() string {
+	return i.String()
+}
+
+// FormatPrint renders the iban in its paper form, i.e. in groups of four characters separated by spaces,
+// as required by ECBS EBS204.
+func (i IBAN) FormatPrint() string {
+	return groupsOfFour(i.String())
+}
+
+// groupsOfFour separates s into groups of four characters, the last group may be shorter.
+func groupsOfFour(s string) string {
+	groups := make([]string, 0, len(s)/4+1)
+	for len(s) > 4 {
+		groups = append(groups, s[:4])
+		s = s[4:]
+	}
+
+	return strings.Join(append(groups, s), " ")
+}
+
+// FormatNational renders the domestic account details of the iban the way they are written within its country,
+// e.g. "sort code 60-16-13, account 31926819" for the UK. Territories use the format of their governing country.
+// Countries without a known national format list their BBAN components. The result is empty if the components are
+// not known, i.e. the country is not supported or the BBAN has the wrong length.
+func (i IBAN) FormatNational() string {
+	if i.AccountNumber == "" {
+		return ""
+	}
+
+	countryCode := i.CountryCode
+	if i.GoverningCountryCode != "" {
+		countryCode = i.GoverningCountryCode
+	}
+	if format, ok := nationalFormats[countryCode]; ok {
+		return format(i)
+	}
+
+	var parts []string
+	for _, component := range []struct{ label, value string }{
+		{label: "bank code", value: i.BankCode},
+		{label: "branch code", value: i.BranchCode},
+		{label: "account", value: i.AccountNumber},
+		{label: "check digits", value: i.NationalCheckDigits},
+	} {
+		if component.value != "" {
+			parts = append(parts, component.label+" "+component.value)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Formats renders the iban in all its formats.
+func (i IBAN) Formats() Formats {
+	return Formats{
+		Electronic: i.FormatElectronic(),
+		Print:      i.FormatPrint(),
+		National:   i.FormatNational(),
+	}
+}
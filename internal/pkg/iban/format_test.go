@@ -0,0 +1,63 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBAN_FormatPrint(t *testing.T) {
+	tests := []struct {
+		name string
+		iban IBAN
+		want string
+	}{
+		{name: "last group shorter", iban: IBAN{CountryCode: "GB", CheckDigits: "29", BBAN: "NWBK60161331926819"}, want: "GB29 NWBK 6016 1331 9268 19"},
+		{name: "last group complete", iban: IBAN{CountryCode: "BE", CheckDigits: "68", BBAN: "539007547034"}, want: "BE68 5390 0754 7034"},
+		{name: "single group", iban: IBAN{CountryCode: "XX", CheckDigits: "00"}, want: "XX00"},
+		{name: "empty", iban: IBAN{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.iban.FormatPrint())
+			require.Equal(t, tt.iban.String(), tt.iban.FormatElectronic())
+		})
+	}
+}
+
+func TestIBAN_FormatNational(t *testing.T) {
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    string
+	}{
+		{name: "GB", ibanStr: "GB29NWBK60161331926819", want: "sort code 60-16-13, account 31926819"},
+		{name: "IE", ibanStr: "IE29AIBK93115212345678", want: "sort code 93-11-52, account 12345678"},
+		{name: "DE", ibanStr: "DE89370400440532013000", want: "bank code 37040044, account 532013000"},
+		{name: "FR", ibanStr: "FR1420041010050500013M02606", want: "bank 20041, branch 01005, account 0500013M026, key 06"},
+		{name: "IT", ibanStr: "IT60X0542811101000000123456", want: "CIN X, ABI 05428, CAB 11101, account 000000123456"},
+		{name: "ES", ibanStr: "ES9121000418450200051332", want: "bank 2100, branch 0418, check digits 45, account 0200051332"},
+		{name: "BE", ibanStr: "BE68539007547034", want: "539-0075470-34"},
+		{name: "territory uses format of governing country", ibanStr: "JE90NWBK60161331926819", want: "sort code 60-16-13, account 31926819"},
+		{name: "other countries list their components", ibanStr: "AL47212110090000000235698741", want: "bank code 212, branch code 1100, account 0000000235698741, check digits 9"},
+		{name: "BBAN with wrong length", ibanStr: "DE8937040044053201300", want: ""},
+		{name: "unsupported country", ibanStr: "XX89370400440532013000", want: ""},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, iban.FormatNational())
+		})
+	}
+}
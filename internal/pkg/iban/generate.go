@@ -0,0 +1,109 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrGenerationNotSupported = errors.New("IBAN cannot be generated from national details for the specified country")
+	ErrMissingComponent       = errors.New("national details are missing a component")
+	ErrUnexpectedComponent    = errors.New("national details have a component the specified country does not use")
+	ErrComponentTooLong       = errors.New("national details have a component that is too long")
+	// ErrBankCodeNotDerivable is returned if the bank code is left out, e.g. for a UK sort code and account number,
+	// and the bank directory does not identify the bank of the branch code.
+	ErrBankCodeNotDerivable = errors.New("bank code is missing and cannot be derived from the branch code")
+)
+
+// swagger:model
+type NationalDetails struct {
+	CountryCode         string `json:"country_code"`
+	BankCode            string `json:"bank_code,omitempty"`
+	BranchCode          string `json:"branch_code,omitempty"`
+	AccountNumber       string `json:"account_number,omitempty"`
+	NationalCheckDigits string `json:"national_check_digits,omitempty"`
+}
+
+// Generate builds the IBAN for the national details, e.g. a German bank code (BLZ) and account number,
+// a UK sort code and account number or a French RIB. Components shorter than the country's format are
+// padded with leading zeros. If the country's BBAN has a bank and branch code and only the branch code is given,
+// e.g. a UK sort code, the bank code is looked up in the bank directory. The generated IBAN is validated before it
+// is returned.
+func (svc *Service) Generate(details NationalDetails) (IBAN, error) {
+	if details.CountryCode == "" {
+		return IBAN{}, ErrCountryCodeEmpty
+	}
+
+	validator, ok := svc.validator(details.CountryCode)
+	if !ok {
+		return IBAN{}, ErrCountryCodeNotSupported
+	}
+
+	if details.BankCode == "" && details.BranchCode != "" && validator.BankCode.End != 0 && validator.BranchCode.End != 0 {
+		bankCode, ok := svc.lookupBankCode(validator, details.BranchCode)
+		if !ok {
+			return IBAN{}, fmt.Errorf("%w: %s", ErrBankCodeNotDerivable, details.BranchCode)
+		}
+		details.BankCode = bankCode
+	}
+
+	bban, err := validator.BuildBBAN(details)
+	if err != nil {
+		return IBAN{}, err
+	}
+
+	checkDigits, err := CheckDigits(details.CountryCode, bban)
+	if err != nil {
+		return IBAN{}, err
+	}
+
+	iban := validator.Annotate(IBAN{CountryCode: details.CountryCode, CheckDigits: checkDigits, BBAN: bban})
+	iban.Bank = svc.lookupBank(iban)
+	iban.Schemes = svc.reachableSchemes(iban)
+	if err = svc.Validate(iban); err != nil {
+		return IBAN{}, err
+	}
+
+	return iban, nil
+}
+
+// BuildBBAN places the components of the national details at their positions within the BBAN.
+// Countries whose BBAN has parts that are not covered by a component are not supported.
+func (c countryValidator) BuildBBAN(details NationalDetails) (string, error) {
+	components := []struct {
+		name  string
+		value string
+		r     bbanRange
+	}{
+		{name: "bank code", value: details.BankCode, r: c.BankCode},
+		{name: "branch code", value: details.BranchCode, r: c.BranchCode},
+		{name: "account number", value: details.AccountNumber, r: c.AccountNumber},
+		{name: "national check digits", value: details.NationalCheckDigits, r: c.NationalCheckDigits},
+	}
+
+	bban := make([]byte, c.BBANStructure.MaxLength())
+	for _, component := range components {
+		length := component.r.End - component.r.Start
+		switch {
+		case component.r.End == 0 && component.value != "":
+			return "", fmt.Errorf("%w: %s", ErrUnexpectedComponent, component.name)
+		case component.r.End == 0:
+			continue
+		case component.value == "":
+			return "", fmt.Errorf("%w: %s", ErrMissingComponent, component.name)
+		case len(component.value) > length:
+			return "", fmt.Errorf("%w: %s has more than %d characters", ErrComponentTooLong, component.name, length)
+		}
+
+		copy(bban[component.r.Start:component.r.End], strings.Repeat("0", length-len(component.value))+component.value)
+	}
+
+	for _, b := range bban {
+		if b == 0 {
+			return "", fmt.Errorf("%w: %s BBAN has parts that are not covered by its components", ErrGenerationNotSupported, c.CountryCode)
+		}
+	}
+
+	return string(bban), nil
+}
@@ -0,0 +1,107 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Generate(t *testing.T) {
+	tests := []struct {
+		name    string
+		details NationalDetails
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "German bank code and account number are padded",
+			details: NationalDetails{CountryCode: "DE", BankCode: "37040044", AccountNumber: "532013000"},
+			want:    "DE89370400440532013000",
+		},
+		{
+			name:    "UK bank code, sort code and account number",
+			details: NationalDetails{CountryCode: "GB", BankCode: "NWBK", BranchCode: "601613", AccountNumber: "31926819"},
+			want:    "GB29NWBK60161331926819",
+		},
+		{
+			name:    "fails for UK sort code without bank code and bank directory",
+			details: NationalDetails{CountryCode: "GB", BranchCode: "601613", AccountNumber: "31926819"},
+			wantErr: ErrBankCodeNotDerivable,
+		},
+		{
+			name: "French RIB",
+			details: NationalDetails{
+				CountryCode:         "FR",
+				BankCode:            "20041",
+				BranchCode:          "01005",
+				AccountNumber:       "0500013M026",
+				NationalCheckDigits: "06",
+			},
+			want: "FR1420041010050500013M02606",
+		},
+		{
+			name: "fails for incorrect RIB key",
+			details: NationalDetails{
+				CountryCode:         "FR",
+				BankCode:            "20041",
+				BranchCode:          "01005",
+				AccountNumber:       "0500013M026",
+				NationalCheckDigits: "07",
+			},
+			wantErr: ErrIncorrectBBANChecksum,
+		},
+		{
+			name:    "fails for letters in a numeric component",
+			details: NationalDetails{CountryCode: "DE", BankCode: "3704004A", AccountNumber: "532013000"},
+			wantErr: ErrIncorrectBBANFormat,
+		},
+		{
+			name:    "fails for missing account number",
+			details: NationalDetails{CountryCode: "DE", BankCode: "37040044"},
+			wantErr: ErrMissingComponent,
+		},
+		{
+			name:    "fails for component the country does not use",
+			details: NationalDetails{CountryCode: "DE", BankCode: "37040044", BranchCode: "1", AccountNumber: "532013000"},
+			wantErr: ErrUnexpectedComponent,
+		},
+		{
+			name:    "fails for too long component",
+			details: NationalDetails{CountryCode: "DE", BankCode: "370400440", AccountNumber: "532013000"},
+			wantErr: ErrComponentTooLong,
+		},
+		{
+			name:    "fails for BBAN parts without component",
+			details: NationalDetails{CountryCode: "TR", BankCode: "00061", AccountNumber: "0519786457841326"},
+			wantErr: ErrGenerationNotSupported,
+		},
+		{
+			name:    "fails for unsupported country",
+			details: NationalDetails{CountryCode: "US", BankCode: "1", AccountNumber: "1"},
+			wantErr: ErrCountryCodeNotSupported,
+		},
+		{
+			name:    "fails for empty country code",
+			details: NationalDetails{BankCode: "1", AccountNumber: "1"},
+			wantErr: ErrCountryCodeEmpty,
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := svc.Generate(tt.details)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Level configures how deep an IBAN is validated. Each level runs the checks of the previous one.
+type Level string
+
+const (
+	// LevelSyntax checks the country, the length and the BBAN format only.
+	LevelSyntax Level = "syntax"
+	// LevelChecksum additionally checks the IBAN check digits, i.e. the ISO 7064 mod 97-10 checksum.
+	LevelChecksum Level = "checksum"
+	// LevelNational additionally checks the national check digits of the BBAN and, if configured, the account.
+	LevelNational Level = "national"
+	// LevelDirectory additionally checks that the bank exists in the bank directory.
+	LevelDirectory Level = "directory"
+)
+
+// levels lists the levels from the cheapest to the most thorough one.
+var levels = []Level{LevelSyntax, LevelChecksum, LevelNational, LevelDirectory}
+
+var (
+	ErrUnknownLevel          = errors.New("validation level is unknown")
+	ErrBankDirectoryRequired = errors.New("validation level requires a bank directory")
+)
+
+// ParseLevel parses the name of a level, e.g. "checksum".
+func ParseLevel(s string) (Level, error) {
+	for _, level := range levels {
+		if string(level) == s {
+			return level, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q, expected one of %v", ErrUnknownLevel, s, levels)
+}
+
+// includes reports whether validating at the level runs the checks of other.
+func (l Level) includes(other Level) bool {
+	return l.rank() >= other.rank()
+}
+
+func (l Level) rank() int {
+	for rank, level := range levels {
+		if level == l {
+			return rank
+		}
+	}
+
+	return -1
+}
+
+// WithLevel makes the Service validate IBANs at the given level instead of LevelNational.
+// LevelDirectory requires a bank directory, see WithBankDirectory.
+func WithLevel(level Level) Option {
+	return func(svc *Service) error {
+		if _, err := ParseLevel(string(level)); err != nil {
+			return err
+		}
+
+		svc.level = level
+		return nil
+	}
+}
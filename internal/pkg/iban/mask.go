@@ -0,0 +1,71 @@
+package iban
+
+// maskChar replaces the characters a MaskPolicy hides.
+const maskChar = '*'
+
+// MaskPolicy configures which characters of an IBAN stay visible when it is masked, all others are hidden.
+type MaskPolicy struct {
+	// KeepCountryAndCheckDigits keeps the first four characters, e.g. "DE89".
+	KeepCountryAndCheckDigits bool
+	// KeepLast keeps the last N characters, e.g. 4 for "3000".
+	KeepLast int
+	// KeepBankCode keeps the bank code, if the country's BBAN format locates it.
+	KeepBankCode bool
+}
+
+var (
+	// MaskPolicyDefault shows the country, check digits and last four characters, e.g. "DE89 **** **** **** **30 00".
+	MaskPolicyDefault = MaskPolicy{KeepCountryAndCheckDigits: true, KeepLast: 4}
+	// MaskPolicyLastFour shows the last four characters only, e.g. "**** **** **** **** **30 00".
+	MaskPolicyLastFour = MaskPolicy{KeepLast: 4}
+	// MaskPolicyBankCode shows the country, check digits and bank code, e.g. "DE89 3704 0044 **** **** **".
+	MaskPolicyBankCode = MaskPolicy{KeepCountryAndCheckDigits: true, KeepBankCode: true}
+)
+
+// Mask renders the iban in its electronic form, with the characters the policy does not keep replaced by "*".
+// Its length is kept, so the masked IBAN can still be recognized. The bank code is located by the country's
+// BBAN format, so it is only kept if the country is supported and the BBAN has the expected length.
+func (svc *Service) Mask(i IBAN, policy MaskPolicy) string {
+	var bankCode bbanRange
+	if validator, ok := svc.validator(i.CountryCode); ok && len(i.BBAN) == validator.BBANStructure.MaxLength() {
+		bankCode = validator.BankCode
+	}
+
+	return mask(i, policy, bankCode)
+}
+
+// MaskPrint renders the masked iban in its paper form, i.e. in groups of four characters separated by spaces.
+func (svc *Service) MaskPrint(i IBAN, policy MaskPolicy) string {
+	return groupsOfFour(svc.Mask(i, policy))
+}
+
+// mask hides the characters of the iban the policy does not keep. bankCode is the position of the bank code
+// within the BBAN, the zero value if it is unknown.
+func mask(i IBAN, policy MaskPolicy, bankCode bbanRange) string {
+	s := []byte(i.String())
+
+	keep := make([]bool, len(s))
+	if policy.KeepCountryAndCheckDigits {
+		for pos := 0; pos < 4 && pos < len(s); pos++ {
+			keep[pos] = true
+		}
+	}
+	for pos := len(s) - policy.KeepLast; pos < len(s); pos++ {
+		if pos >= 0 {
+			keep[pos] = true
+		}
+	}
+	if policy.KeepBankCode && bankCode.End <= len(i.BBAN) {
+		for pos := 4 + bankCode.Start; pos < 4+bankCode.End; pos++ {
+			keep[pos] = true
+		}
+	}
+
+	for pos := range s {
+		if !keep[pos] {
+			s[pos] = maskChar
+		}
+	}
+
+	return string(s)
+}
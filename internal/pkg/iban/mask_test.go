@@ -0,0 +1,95 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Mask(t *testing.T) {
+	de := IBAN{CountryCode: "DE", CheckDigits: "89", BBAN: "370400440532013000", BankCode: "37040044", AccountNumber: "0532013000"}
+	it := IBAN{CountryCode: "IT", CheckDigits: "60", BBAN: "X0542811101000000123456", BankCode: "05428", BranchCode: "11101", AccountNumber: "000000123456", NationalCheckDigits: "X"}
+
+	tests := []struct {
+		name      string
+		iban      IBAN
+		policy    MaskPolicy
+		want      string
+		wantPrint string
+	}{
+		{
+			name:      "default",
+			iban:      de,
+			policy:    MaskPolicyDefault,
+			want:      "DE89**************3000",
+			wantPrint: "DE89 **** **** **** **30 00",
+		},
+		{
+			name:      "last four",
+			iban:      de,
+			policy:    MaskPolicyLastFour,
+			want:      "******************3000",
+			wantPrint: "**** **** **** **** **30 00",
+		},
+		{
+			name:      "bank code",
+			iban:      de,
+			policy:    MaskPolicyBankCode,
+			want:      "DE8937040044**********",
+			wantPrint: "DE89 3704 0044 **** **** **",
+		},
+		{
+			name:      "bank code after national check digit",
+			iban:      it,
+			policy:    MaskPolicy{KeepBankCode: true},
+			want:      "*****05428*****************",
+			wantPrint: "**** *054 28** **** **** **** ***",
+		},
+		{
+			name:      "bank code unknown",
+			iban:      IBAN{CountryCode: "DE", CheckDigits: "89", BBAN: "37040044053201300"},
+			policy:    MaskPolicy{KeepBankCode: true},
+			want:      "*********************",
+			wantPrint: "**** **** **** **** **** *",
+		},
+		{
+			name:      "bank code of IBAN that was not parsed",
+			iban:      IBAN{CountryCode: "GB", CheckDigits: "29", BBAN: "NWBK60161331926819"},
+			policy:    MaskPolicyBankCode,
+			want:      "GB29NWBK**************",
+			wantPrint: "GB29 NWBK **** **** **** **",
+		},
+		{
+			name:      "bank code of unsupported country",
+			iban:      IBAN{CountryCode: "US", CheckDigits: "12", BBAN: "370400440532013000"},
+			policy:    MaskPolicy{KeepBankCode: true},
+			want:      "**********************",
+			wantPrint: "**** **** **** **** **** **",
+		},
+		{
+			name:      "zero value hides everything",
+			iban:      de,
+			want:      "**********************",
+			wantPrint: "**** **** **** **** **** **",
+		},
+		{
+			name:      "keep more than the length",
+			iban:      de,
+			policy:    MaskPolicy{KeepLast: 30},
+			want:      "DE89370400440532013000",
+			wantPrint: "DE89 3704 0044 0532 0130 00",
+		},
+	}
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.Equal(t, tt.want, svc.Mask(tt.iban, tt.policy))
+			require.Equal(t, tt.wantPrint, svc.MaskPrint(tt.iban, tt.policy))
+		})
+	}
+}
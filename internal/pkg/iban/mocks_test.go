@@ -1,11 +1,24 @@
 package iban
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
+)
 
 type mockParser struct {
-	t            *testing.T
-	ParseFunc    func(string) (IBAN, error)
-	ValidateFunc func(IBAN) error
+	t                 *testing.T
+	ParseFunc         func(string) (IBAN, error)
+	ParseAtFunc       func(string, time.Time) (IBAN, error)
+	ValidateFunc      func(IBAN) error
+	ValidateLevelFunc func(IBAN, time.Time, Level) (ValidationResult, error)
+	SuggestFunc       func(IBAN) []Suggestion
+	RepairFunc        func(IBAN) (IBAN, error)
+	GenerateFunc      func(NationalDetails) (IBAN, error)
+	MaskFunc          func(IBAN, MaskPolicy) string
+	CrossCheckFunc    func(IBAN, bic.BIC) error
+	ExtractFunc       func(string) []Match
 }
 
 func (p *mockParser) Parse(s string) (IBAN, error) {
@@ -15,9 +28,86 @@ func (p *mockParser) Parse(s string) (IBAN, error) {
 	return p.ParseFunc(s)
 }
 
+func (p *mockParser) ParseAt(s string, t time.Time) (IBAN, error) {
+	if p.ParseAtFunc == nil {
+		p.t.Fatalf("mockParser.ParseAtFunc: method is nil but Parser.ParseAt was just called")
+	}
+	return p.ParseAtFunc(s, t)
+}
+
 func (p *mockParser) Validate(i IBAN) error {
 	if p.ValidateFunc == nil {
 		p.t.Fatalf("mockParser.ValidateFunc: method is nil but Parser.Validate was just called")
 	}
 	return p.ValidateFunc(i)
 }
+
+func (p *mockParser) ValidateLevel(i IBAN, t time.Time, level Level) (ValidationResult, error) {
+	if p.ValidateLevelFunc == nil {
+		p.t.Fatalf("mockParser.ValidateLevelFunc: method is nil but Parser.ValidateLevel was just called")
+	}
+	return p.ValidateLevelFunc(i, t, level)
+}
+
+func (p *mockParser) Suggest(i IBAN) []Suggestion {
+	if p.SuggestFunc == nil {
+		p.t.Fatalf("mockParser.SuggestFunc: method is nil but Parser.Suggest was just called")
+	}
+	return p.SuggestFunc(i)
+}
+
+func (p *mockParser) Repair(i IBAN) (IBAN, error) {
+	if p.RepairFunc == nil {
+		p.t.Fatalf("mockParser.RepairFunc: method is nil but Parser.Repair was just called")
+	}
+	return p.RepairFunc(i)
+}
+
+func (p *mockParser) Generate(d NationalDetails) (IBAN, error) {
+	if p.GenerateFunc == nil {
+		p.t.Fatalf("mockParser.GenerateFunc: method is nil but Parser.Generate was just called")
+	}
+	return p.GenerateFunc(d)
+}
+
+func (p *mockParser) CrossCheck(i IBAN, b bic.BIC) error {
+	if p.CrossCheckFunc == nil {
+		p.t.Fatalf("mockParser.CrossCheckFunc: method is nil but Parser.CrossCheck was just called")
+	}
+	return p.CrossCheckFunc(i, b)
+}
+
+func (p *mockParser) Extract(text string) []Match {
+	if p.ExtractFunc == nil {
+		p.t.Fatalf("mockParser.ExtractFunc: method is nil but Parser.Extract was just called")
+	}
+	return p.ExtractFunc(text)
+}
+
+// Mask is called for every response with an IBAN, so it masks without a bank code unless MaskFunc is set.
+func (p *mockParser) Mask(i IBAN, policy MaskPolicy) string {
+	if p.MaskFunc == nil {
+		return mask(i, policy, bbanRange{})
+	}
+	return p.MaskFunc(i, policy)
+}
+
+type mockBICParser struct {
+	t            *testing.T
+	ParseFunc    func(string) (bic.BIC, error)
+	ValidateFunc func(bic.BIC) error
+}
+
+func (p *mockBICParser) Parse(s string) (bic.BIC, error) {
+	if p.ParseFunc == nil {
+		p.t.Fatalf("mockBICParser.ParseFunc: method is nil but Parser.Parse was just called")
+	}
+	return p.ParseFunc(s)
+}
+
+func (p *mockBICParser) Validate(b bic.BIC) error {
+	if p.ValidateFunc == nil {
+		p.t.Fatalf("mockBICParser.ValidateFunc: method is nil but Parser.Validate was just called")
+	}
+	return p.ValidateFunc(b)
+}
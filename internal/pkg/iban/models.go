@@ -1,12 +1,52 @@
 package iban
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/bic"
+)
 
 // swagger:model
 type IBAN struct {
 	CountryCode string `json:"country_code"`
 	CheckDigits string `json:"check_digits"`
 	BBAN        string `json:"bban"`
+
+	// GoverningCountryCode is the country whose IBAN format is followed, only set if CountryCode is a territory,
+	// e.g. FR for French Guiana (GF).
+	GoverningCountryCode string `json:"governing_country_code,omitempty"`
+
+	// RegistryStatus is "experimental" if the country's format is not part of the SWIFT IBAN Registry,
+	// empty otherwise.
+	RegistryStatus string `json:"registry_status,omitempty"`
+
+	// SEPA and EEA are set if the country is part of the SEPA scheme or the European Economic Area.
+	SEPA bool `json:"sepa"`
+	EEA  bool `json:"eea"`
+	// Currency is the ISO 4217 code of the country's currency, only set if the country is supported.
+	Currency string `json:"currency,omitempty"`
+
+	// BBAN components, only set if the country is supported and the BBAN has the expected length.
+	BankCode            string `json:"bank_code,omitempty"`
+	BranchCode          string `json:"branch_code,omitempty"`
+	AccountNumber       string `json:"account_number,omitempty"`
+	NationalCheckDigits string `json:"national_check_digits,omitempty"`
+
+	// Bank is the bank identified by the bank and branch code, only set if it is listed in the bank directory.
+	Bank *Bank `json:"bank,omitempty"`
+
+	// Schemes are the SEPA schemes the bank is reachable by, i.e. "SCT", "SCT_INST", "SDD_CORE" and "SDD_B2B",
+	// only set if the bank's BIC is listed in the EPC register of participants.
+	Schemes []string `json:"schemes,omitempty"`
+}
+
+// swagger:model
+type Bank struct {
+	Name       string `json:"name"`
+	BIC        string `json:"bic,omitempty"`
+	Street     string `json:"street,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	City       string `json:"city,omitempty"`
 }
 
 func (i IBAN) String() string {
@@ -18,4 +58,53 @@ type httpResponse struct {
 	Error   *string `json:"error"`
 	IsValid bool    `json:"is_valid"`
 	IBAN    *IBAN   `json:"iban"`
+
+	// ErrorDetails describe the error in a machine-readable way, only set if the IBAN is invalid.
+	ErrorDetails *ValidationError `json:"error_details,omitempty"`
+
+	// Formats are the ways to render the IBAN, only set if the IBAN could be parsed.
+	Formats *Formats `json:"formats,omitempty"`
+
+	// Normalizations are the transformations applied to the given IBAN string before parsing it,
+	// e.g. "removed_whitespace" or "upper_cased".
+	Normalizations []string `json:"normalizations,omitempty"`
+
+	// Checks are the outcomes of every validation check, only set by the validate endpoint.
+	Checks []Check `json:"checks,omitempty"`
+
+	// Suggestions are IBANs the given one was probably mistyped from, only set if its checksum is incorrect.
+	Suggestions []Suggestion `json:"suggestions,omitempty"`
+
+	// BIC is the BIC the IBAN was cross-checked with.
+	BIC *bic.BIC `json:"bic,omitempty"`
+
+	// MismatchReason is set if the BIC does not match the IBAN, either "country" or "bank".
+	MismatchReason string `json:"mismatch_reason,omitempty"`
+}
+
+// swagger:model
+type checkDigitsHTTPResponse struct {
+	Error       *string `json:"error"`
+	CountryCode string  `json:"country_code"`
+	BBAN        string  `json:"bban"`
+	// CheckDigits and IBAN are the computed check digits and the resulting IBAN, only set if they could be computed.
+	CheckDigits string `json:"check_digits,omitempty"`
+	IBAN        string `json:"iban,omitempty"`
+}
+
+// swagger:model
+type extractedIBAN struct {
+	// Start and End are the byte offsets of the IBAN's first character and of the byte after its last character.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// Text is the IBAN as written in the text, including separators.
+	Text string `json:"text"`
+
+	httpResponse
+}
+
+// swagger:model
+type extractHTTPResponse struct {
+	Error   *string         `json:"error"`
+	Matches []extractedIBAN `json:"matches"`
 }
@@ -0,0 +1,108 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Transformations Normalize applies to pasted IBANs, in the order they are reported.
+const (
+	NormalizationRemovedQuotes      = "removed_quotes"       // surrounding quotes, e.g. "DE89…" or «DE89…»
+	NormalizationRemovedLabel       = "removed_label"        // a leading "IBAN" or "IBAN:" label
+	NormalizationRemovedWhitespace  = "removed_whitespace"   // spaces, tabs, line breaks and non-breaking spaces
+	NormalizationRemovedInvisible   = "removed_invisible"    // zero-width spaces, joiners and byte order marks
+	NormalizationRemovedSeparators  = "removed_separators"   // dashes and dots
+	NormalizationConvertedFullWidth = "converted_full_width" // full-width digits and letters, e.g. "８９"
+	NormalizationUpperCased         = "upper_cased"          // lower-case letters
+)
+
+var normalizations = []string{
+	NormalizationRemovedQuotes,
+	NormalizationRemovedLabel,
+	NormalizationRemovedWhitespace,
+	NormalizationRemovedInvisible,
+	NormalizationRemovedSeparators,
+	NormalizationConvertedFullWidth,
+	NormalizationUpperCased,
+}
+
+var ErrNotCanonical = errors.New("IBAN is not in its canonical electronic form")
+
+// quotes maps opening quotes to their closing ones.
+var quotes = map[rune]rune{'"': '"', '\'': '\'', '`': '`', '“': '”', '„': '“', '‘': '’', '«': '»', '»': '«'}
+
+// invisibles are the zero-width characters that are commonly copied along with text.
+var invisibles = map[rune]bool{'\u200b': true, '\u200c': true, '\u200d': true, '\u2060': true, '\ufeff': true}
+
+// Normalize cleans up a pasted or typed iban string, so it can be parsed, and returns the transformations it applied.
+// It removes surrounding quotes, a leading "IBAN:" label, whitespace, zero-width characters, dashes and dots,
+// converts full-width characters to their ASCII form and upper-cases letters.
+func Normalize(ibanStr string) (string, []string) {
+	applied := map[string]bool{}
+
+	s := strings.TrimFunc(ibanStr, unicode.IsSpace)
+	if s != ibanStr {
+		applied[NormalizationRemovedWhitespace] = true
+	}
+
+	// quotes and the label can enclose each other, e.g. IBAN: "DE89…", so both are removed until neither is left
+	for {
+		if runes := []rune(s); len(runes) >= 2 && quotes[runes[0]] != 0 && quotes[runes[0]] == runes[len(runes)-1] {
+			s = strings.TrimFunc(string(runes[1:len(runes)-1]), unicode.IsSpace)
+			applied[NormalizationRemovedQuotes] = true
+			continue
+		}
+
+		if len(s) >= 4 && strings.EqualFold(s[:4], "IBAN") {
+			s = strings.TrimLeftFunc(strings.TrimPrefix(s[4:], ":"), unicode.IsSpace)
+			applied[NormalizationRemovedLabel] = true
+			continue
+		}
+
+		break
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			applied[NormalizationRemovedWhitespace] = true
+		case invisibles[r]:
+			applied[NormalizationRemovedInvisible] = true
+		case r == '.' || r == '．' || unicode.Is(unicode.Pd, r):
+			applied[NormalizationRemovedSeparators] = true
+		case r >= '０' && r <= '９' || r >= 'Ａ' && r <= 'Ｚ' || r >= 'ａ' && r <= 'ｚ':
+			b.WriteRune(r - '０' + '0') // the full-width forms are offset by the same amount from ASCII
+			applied[NormalizationConvertedFullWidth] = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	s = b.String()
+	if upper := strings.ToUpper(s); upper != s {
+		s = upper
+		applied[NormalizationUpperCased] = true
+	}
+
+	var transformations []string
+	for _, n := range normalizations {
+		if applied[n] {
+			transformations = append(transformations, n)
+		}
+	}
+
+	return s, transformations
+}
+
+// NormalizeStrict returns the iban string if it is in its canonical electronic form, i.e. Normalize would not
+// change it, and ErrNotCanonical listing the necessary transformations otherwise.
+func NormalizeStrict(ibanStr string) (string, error) {
+	if _, transformations := Normalize(ibanStr); len(transformations) > 0 {
+		return "", fmt.Errorf("%w: %s", ErrNotCanonical, strings.Join(transformations, ", "))
+	}
+
+	return ibanStr, nil
+}
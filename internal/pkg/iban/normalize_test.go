@@ -0,0 +1,77 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    string
+		wantN   []string
+	}{
+		{name: "canonical", ibanStr: "DE89370400440532013000", want: "DE89370400440532013000"},
+		{name: "print format", ibanStr: "DE89 3704 0044 0532 0130 00", want: "DE89370400440532013000", wantN: []string{NormalizationRemovedWhitespace}},
+		{name: "surrounding whitespace", ibanStr: " \tDE89370400440532013000\n", want: "DE89370400440532013000", wantN: []string{NormalizationRemovedWhitespace}},
+		{name: "non-breaking spaces", ibanStr: "DE89\u00a03704\u202f0044", want: "DE8937040044", wantN: []string{NormalizationRemovedWhitespace}},
+		{name: "dashes and dots", ibanStr: "DE89-3704.0044–0532", want: "DE89370400440532", wantN: []string{NormalizationRemovedSeparators}},
+		{name: "zero-width characters", ibanStr: "\ufeffDE89\u200b3704\u200d0044", want: "DE8937040044", wantN: []string{NormalizationRemovedInvisible}},
+		{name: "full-width characters", ibanStr: "ＤＥ８９３７０４", want: "DE893704", wantN: []string{NormalizationConvertedFullWidth}},
+		{name: "lower case", ibanStr: "gb29nwbk60161331926819", want: "GB29NWBK60161331926819", wantN: []string{NormalizationUpperCased}},
+		{name: "full-width lower case", ibanStr: "ｇｂ２９", want: "GB29", wantN: []string{NormalizationConvertedFullWidth, NormalizationUpperCased}},
+		{name: "label", ibanStr: "IBAN: DE89370400440532013000", want: "DE89370400440532013000", wantN: []string{NormalizationRemovedLabel}},
+		{name: "label without colon", ibanStr: "iban DE89370400440532013000", want: "DE89370400440532013000", wantN: []string{NormalizationRemovedLabel}},
+		{name: "quotes", ibanStr: `"DE89370400440532013000"`, want: "DE89370400440532013000", wantN: []string{NormalizationRemovedQuotes}},
+		{name: "nested typographic quotes", ibanStr: "«'DE89370400440532013000'»", want: "DE89370400440532013000", wantN: []string{NormalizationRemovedQuotes}},
+		{
+			name:    "quotes after label",
+			ibanStr: `IBAN: "DE89 3704 0044 0532 0130 00"`,
+			want:    "DE89370400440532013000",
+			wantN:   []string{NormalizationRemovedQuotes, NormalizationRemovedLabel, NormalizationRemovedWhitespace},
+		},
+		{
+			name:    "label within quotes within quotes",
+			ibanStr: `'IBAN "DE89370400440532013000"'`,
+			want:    "DE89370400440532013000",
+			wantN:   []string{NormalizationRemovedQuotes, NormalizationRemovedLabel},
+		},
+		{name: "unbalanced quotes are kept", ibanStr: `"DE89370400440532013000`, want: `"DE89370400440532013000`},
+		{
+			name:    "everything at once",
+			ibanStr: " “IBAN: de89 3704-0044.0532\u200b0130 ００” ",
+			want:    "DE89370400440532013000",
+			wantN: []string{
+				NormalizationRemovedQuotes,
+				NormalizationRemovedLabel,
+				NormalizationRemovedWhitespace,
+				NormalizationRemovedInvisible,
+				NormalizationRemovedSeparators,
+				NormalizationConvertedFullWidth,
+				NormalizationUpperCased,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, gotN := Normalize(tt.ibanStr)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantN, gotN)
+		})
+	}
+}
+
+func TestNormalizeStrict(t *testing.T) {
+	got, err := NormalizeStrict("DE89370400440532013000")
+	require.NoError(t, err)
+	require.Equal(t, "DE89370400440532013000", got)
+
+	_, err = NormalizeStrict("de89 3704 0044 0532 0130 00")
+	require.ErrorIs(t, err, ErrNotCanonical)
+	require.EqualError(t, err, "IBAN is not in its canonical electronic form: removed_whitespace, upper_cased")
+}
@@ -0,0 +1,200 @@
+package iban
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// defaultRegistry is the SWIFT IBAN Registry export that ships with the binary.
+	//go:embed data/registry.json
+	defaultRegistry []byte
+
+	ErrInvalidRegistry = errors.New("invalid IBAN registry")
+)
+
+// dateLayout is the layout of the registry's effective dates and the validation date of the HTTP API.
+const dateLayout = "2006-01-02"
+
+// registry is the JSON representation of the SWIFT IBAN Registry export.
+type registry struct {
+	Countries []registryEntry `json:"countries"`
+}
+
+// registryEntry describes the IBAN format of a single country as published in the SWIFT IBAN Registry.
+type registryEntry struct {
+	CountryCode   string `json:"country_code"`
+	CountryName   string `json:"country_name"`
+	IBANLength    int    `json:"iban_length"`
+	BBANStructure string `json:"bban_structure"`
+	IBANExample   string `json:"iban_example"`
+
+	// 1-based, inclusive positions within the BBAN, e.g. "1-4" or "8"
+	BankIdentifierPosition      string `json:"bank_identifier_position"`
+	BranchIdentifierPosition    string `json:"branch_identifier_position"`
+	AccountNumberPosition       string `json:"account_number_position"`
+	NationalCheckDigitsPosition string `json:"national_check_digits_position"`
+
+	// first and last day ("2006-01-02") the entry's rules are in effect, both optional. A country may be listed
+	// more than once, if the effective dates of its entries don't overlap.
+	EffectiveFrom  string `json:"effective_from"`
+	EffectiveUntil string `json:"effective_until"`
+}
+
+// loadRegistry reads a registry export from r and builds a countryValidator for every country listed in it.
+func loadRegistry(r io.Reader) (map[string]countryValidator, error) {
+	var reg registry
+	if err := json.NewDecoder(r).Decode(&reg); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode registry: %s", ErrInvalidRegistry, err)
+	}
+
+	if len(reg.Countries) == 0 {
+		return nil, fmt.Errorf("%w: registry does not contain any countries", ErrInvalidRegistry)
+	}
+
+	revisions := make(map[string][]countryValidator, len(reg.Countries))
+	for _, entry := range reg.Countries {
+		validator, err := entry.countryValidator()
+		if err != nil {
+			return nil, fmt.Errorf("%w: country %q: %s", ErrInvalidRegistry, entry.CountryCode, err)
+		}
+
+		revisions[validator.CountryCode] = append(revisions[validator.CountryCode], validator)
+	}
+
+	validators := make(map[string]countryValidator, len(revisions))
+	for countryCode, r := range revisions {
+		validator, err := mergeRevisions(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: country %q: %s", ErrInvalidRegistry, countryCode, err)
+		}
+		validators[countryCode] = validator
+	}
+
+	return validators, nil
+}
+
+// mergeRevisions returns the latest of the country's rules, holding the earlier ones as its revisions.
+// The effective dates of the rules must not overlap.
+func mergeRevisions(revisions []countryValidator) (countryValidator, error) {
+	sort.Slice(revisions, func(a, b int) bool {
+		return revisions[a].EffectiveFrom.After(revisions[b].EffectiveFrom)
+	})
+
+	for i := 1; i < len(revisions); i++ {
+		newer, older := revisions[i-1], revisions[i]
+		if older.EffectiveUntil.IsZero() || !older.EffectiveUntil.Before(newer.EffectiveFrom) {
+			return countryValidator{}, errors.New("country is listed more than once with overlapping effective dates")
+		}
+	}
+
+	latest := revisions[0]
+	latest.Revisions = revisions[1:]
+
+	return latest, nil
+}
+
+// countryValidator builds the countryValidator described by the registry entry.
+func (e registryEntry) countryValidator() (countryValidator, error) {
+	if len(e.CountryCode) != 2 || strings.ToUpper(e.CountryCode) != e.CountryCode {
+		return countryValidator{}, errors.New("country code must consist of two uppercase letters")
+	}
+
+	bbanStructure, err := parseBBANStructure(e.BBANStructure)
+	if err != nil {
+		return countryValidator{}, err
+	}
+
+	// country code and check digits take up the first four characters of every IBAN
+	if e.IBANLength != bbanStructure.MaxLength()+4 {
+		return countryValidator{}, fmt.Errorf(
+			"iban length %d does not match bban structure %q", e.IBANLength, e.BBANStructure,
+		)
+	}
+
+	validator := countryValidator{
+		CountryCode:   e.CountryCode,
+		Length:        e.IBANLength,
+		BBANStructure: bbanStructure,
+		BBANChecksum:  bbanChecksums[e.CountryCode],
+	}
+
+	dates := []struct {
+		name   string
+		date   string
+		target *time.Time
+	}{
+		{name: "effective from", date: e.EffectiveFrom, target: &validator.EffectiveFrom},
+		{name: "effective until", date: e.EffectiveUntil, target: &validator.EffectiveUntil},
+	}
+	for _, d := range dates {
+		if d.date == "" {
+			continue
+		}
+
+		date, err := time.Parse(dateLayout, d.date)
+		if err != nil {
+			return countryValidator{}, fmt.Errorf("invalid %s date %q", d.name, d.date)
+		}
+		*d.target = date
+	}
+
+	if !validator.EffectiveUntil.IsZero() && validator.EffectiveUntil.Before(validator.EffectiveFrom) {
+		return countryValidator{}, errors.New("effective until date is before effective from date")
+	}
+
+	positions := []struct {
+		name     string
+		position string
+		target   *bbanRange
+	}{
+		{name: "bank identifier", position: e.BankIdentifierPosition, target: &validator.BankCode},
+		{name: "branch identifier", position: e.BranchIdentifierPosition, target: &validator.BranchCode},
+		{name: "account number", position: e.AccountNumberPosition, target: &validator.AccountNumber},
+		{name: "national check digits", position: e.NationalCheckDigitsPosition, target: &validator.NationalCheckDigits},
+	}
+	for _, p := range positions {
+		if p.position == "" {
+			continue
+		}
+
+		r, err := parsePosition(p.position, bbanStructure.MaxLength())
+		if err != nil {
+			return countryValidator{}, fmt.Errorf("invalid %s position: %w", p.name, err)
+		}
+		*p.target = r
+	}
+
+	return validator, nil
+}
+
+// parsePosition parses a 1-based, inclusive position like "5-8" or "8" into a bbanRange.
+func parsePosition(position string, bbanLength int) (bbanRange, error) {
+	from, to, found := strings.Cut(position, "-")
+	if !found {
+		to = from
+	}
+
+	start, err := strconv.Atoi(from)
+	if err != nil {
+		return bbanRange{}, fmt.Errorf("invalid position %q", position)
+	}
+
+	end, err := strconv.Atoi(to)
+	if err != nil {
+		return bbanRange{}, fmt.Errorf("invalid position %q", position)
+	}
+
+	if start < 1 || start > end || end > bbanLength {
+		return bbanRange{}, fmt.Errorf("position %q is out of the bban's bounds", position)
+	}
+
+	return bbanRange{Start: start - 1, End: end}, nil
+}
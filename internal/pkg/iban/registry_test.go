@@ -0,0 +1,157 @@
+package iban
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry string
+		want     map[string]int // country code => iban length
+		wantErr  error
+	}{
+		{
+			name: "success",
+			registry: `{"countries": [
+				{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n"},
+				{"country_code": "NL", "iban_length": 18, "bban_structure": "4!a10!n"}
+			]}`,
+			want: map[string]int{"GB": 22, "NL": 18},
+		},
+		{
+			name:     "fails for malformed json",
+			registry: `{"countries": [`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for empty registry",
+			registry: `{"countries": []}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for invalid country code",
+			registry: `{"countries": [{"country_code": "gb", "iban_length": 22, "bban_structure": "4!a6!n8!n"}]}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for unsupported bban structure",
+			registry: `{"countries": [{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!x8!n"}]}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for invalid bank identifier position",
+			registry: `{"countries": [{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n", "bank_identifier_position": "1-19"}]}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for iban length not matching bban structure",
+			registry: `{"countries": [{"country_code": "GB", "iban_length": 21, "bban_structure": "4!a6!n8!n"}]}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name: "success with revisions",
+			registry: `{"countries": [
+				{"country_code": "GB", "iban_length": 20, "bban_structure": "4!a6!n6!n", "effective_until": "2019-12-31"},
+				{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n", "effective_from": "2020-01-01"}
+			]}`,
+			want: map[string]int{"GB": 22},
+		},
+		{
+			name: "fails for overlapping revisions",
+			registry: `{"countries": [
+				{"country_code": "GB", "iban_length": 20, "bban_structure": "4!a6!n6!n", "effective_until": "2020-01-01"},
+				{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n", "effective_from": "2020-01-01"}
+			]}`,
+			wantErr: ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for invalid effective date",
+			registry: `{"countries": [{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n", "effective_from": "01.01.2020"}]}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name:     "fails for effective until date before effective from date",
+			registry: `{"countries": [{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n", "effective_from": "2020-01-01", "effective_until": "2019-12-31"}]}`,
+			wantErr:  ErrInvalidRegistry,
+		},
+		{
+			name: "fails for duplicate country",
+			registry: `{"countries": [
+				{"country_code": "NL", "iban_length": 18, "bban_structure": "4!a10!n"},
+				{"country_code": "NL", "iban_length": 18, "bban_structure": "4!a10!n"}
+			]}`,
+			wantErr: ErrInvalidRegistry,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := loadRegistry(strings.NewReader(tt.registry))
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Len(t, got, len(tt.want))
+			for countryCode, length := range tt.want {
+				require.Equal(t, countryCode, got[countryCode].CountryCode)
+				require.Equal(t, length, got[countryCode].Length)
+				require.NotEmpty(t, got[countryCode].BBANStructure.String())
+			}
+		})
+	}
+}
+
+func Test_parsePosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		want     bbanRange
+		wantErr  bool
+	}{
+		{name: "range", position: "5-8", want: bbanRange{Start: 4, End: 8}},
+		{name: "single character", position: "1", want: bbanRange{Start: 0, End: 1}},
+		{name: "up to the end of the bban", position: "11-18", want: bbanRange{Start: 10, End: 18}},
+		{name: "fails for position beyond the bban", position: "11-19", wantErr: true},
+		{name: "fails for zero position", position: "0-4", wantErr: true},
+		{name: "fails for reversed range", position: "8-5", wantErr: true},
+		{name: "fails for non-numeric position", position: "a-b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := parsePosition(tt.position, 18)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewService_WithRegistry(t *testing.T) {
+	pinned := `{"countries": [{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n"}]}`
+
+	svc, err := NewService(WithRegistry(strings.NewReader(pinned)))
+	require.NoError(t, err)
+	require.Len(t, svc.validators, 1)
+
+	iban, err := svc.Parse("GB29NWBK60161331926819")
+	require.NoError(t, err)
+	require.NoError(t, svc.Validate(iban))
+
+	iban, err = svc.Parse("NL91ABNA0417164300")
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.Validate(iban), ErrCountryCodeNotSupported)
+
+	_, err = NewService(WithRegistry(bytes.NewReader([]byte(`{}`))))
+	require.ErrorIs(t, err, ErrInvalidRegistry)
+}
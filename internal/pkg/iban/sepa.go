@@ -0,0 +1,170 @@
+package iban
+
+import "github.com/ymakhloufi/pfc/internal/pkg/epc"
+
+// countryInfo describes a country's membership in the SEPA scheme and the EEA, and the currency of its accounts.
+type countryInfo struct {
+	SEPA     bool
+	EEA      bool
+	Currency string // ISO 4217
+}
+
+// countries lists the countries and territories with IBAN rules, including the experimental ones.
+var countries = map[string]countryInfo{
+	"AD": {SEPA: true, Currency: "EUR"},
+	"AE": {Currency: "AED"},
+	"AL": {SEPA: true, Currency: "ALL"},
+	"AO": {Currency: "AOA"},
+	"AT": {SEPA: true, EEA: true, Currency: "EUR"},
+	"AX": {SEPA: true, EEA: true, Currency: "EUR"},
+	"AZ": {Currency: "AZN"},
+	"BA": {Currency: "BAM"},
+	"BE": {SEPA: true, EEA: true, Currency: "EUR"},
+	"BF": {Currency: "XOF"},
+	"BG": {SEPA: true, EEA: true, Currency: "EUR"},
+	"BH": {Currency: "BHD"},
+	"BI": {Currency: "BIF"},
+	"BJ": {Currency: "XOF"},
+	"BL": {SEPA: true, Currency: "EUR"},
+	"BR": {Currency: "BRL"},
+	"BY": {Currency: "BYN"},
+	"CF": {Currency: "XAF"},
+	"CG": {Currency: "XAF"},
+	"CH": {SEPA: true, Currency: "CHF"},
+	"CI": {Currency: "XOF"},
+	"CM": {Currency: "XAF"},
+	"CR": {Currency: "CRC"},
+	"CV": {Currency: "CVE"},
+	"CY": {SEPA: true, EEA: true, Currency: "EUR"},
+	"CZ": {SEPA: true, EEA: true, Currency: "CZK"},
+	"DE": {SEPA: true, EEA: true, Currency: "EUR"},
+	"DJ": {Currency: "DJF"},
+	"DK": {SEPA: true, EEA: true, Currency: "DKK"},
+	"DO": {Currency: "DOP"},
+	"DZ": {Currency: "DZD"},
+	"EE": {SEPA: true, EEA: true, Currency: "EUR"},
+	"EG": {Currency: "EGP"},
+	"ES": {SEPA: true, EEA: true, Currency: "EUR"},
+	"FI": {SEPA: true, EEA: true, Currency: "EUR"},
+	"FK": {Currency: "FKP"},
+	"FO": {Currency: "DKK"},
+	"FR": {SEPA: true, EEA: true, Currency: "EUR"},
+	"GA": {Currency: "XAF"},
+	"GB": {SEPA: true, Currency: "GBP"},
+	"GE": {Currency: "GEL"},
+	"GF": {SEPA: true, EEA: true, Currency: "EUR"},
+	"GG": {SEPA: true, Currency: "GBP"},
+	"GI": {SEPA: true, Currency: "GIP"},
+	"GL": {Currency: "DKK"},
+	"GP": {SEPA: true, EEA: true, Currency: "EUR"},
+	"GQ": {Currency: "XAF"},
+	"GR": {SEPA: true, EEA: true, Currency: "EUR"},
+	"GT": {Currency: "GTQ"},
+	"GW": {Currency: "XOF"},
+	"HN": {Currency: "HNL"},
+	"HR": {SEPA: true, EEA: true, Currency: "EUR"},
+	"HU": {SEPA: true, EEA: true, Currency: "HUF"},
+	"IE": {SEPA: true, EEA: true, Currency: "EUR"},
+	"IL": {Currency: "ILS"},
+	"IM": {SEPA: true, Currency: "GBP"},
+	"IQ": {Currency: "IQD"},
+	"IR": {Currency: "IRR"},
+	"IS": {SEPA: true, EEA: true, Currency: "ISK"},
+	"IT": {SEPA: true, EEA: true, Currency: "EUR"},
+	"JE": {SEPA: true, Currency: "GBP"},
+	"JO": {Currency: "JOD"},
+	"KM": {Currency: "KMF"},
+	"KW": {Currency: "KWD"},
+	"KZ": {Currency: "KZT"},
+	"LB": {Currency: "LBP"},
+	"LC": {Currency: "XCD"},
+	"LI": {SEPA: true, EEA: true, Currency: "CHF"},
+	"LT": {SEPA: true, EEA: true, Currency: "EUR"},
+	"LU": {SEPA: true, EEA: true, Currency: "EUR"},
+	"LV": {SEPA: true, EEA: true, Currency: "EUR"},
+	"LY": {Currency: "LYD"},
+	"MA": {Currency: "MAD"},
+	"MC": {SEPA: true, Currency: "EUR"},
+	"MD": {SEPA: true, Currency: "MDL"},
+	"ME": {SEPA: true, Currency: "EUR"},
+	"MF": {SEPA: true, EEA: true, Currency: "EUR"},
+	"MG": {Currency: "MGA"},
+	"MK": {SEPA: true, Currency: "MKD"},
+	"ML": {Currency: "XOF"},
+	"MN": {Currency: "MNT"},
+	"MQ": {SEPA: true, EEA: true, Currency: "EUR"},
+	"MR": {Currency: "MRU"},
+	"MT": {SEPA: true, EEA: true, Currency: "EUR"},
+	"MU": {Currency: "MUR"},
+	"MZ": {Currency: "MZN"},
+	"NC": {Currency: "XPF"},
+	"NE": {Currency: "XOF"},
+	"NI": {Currency: "NIO"},
+	"NL": {SEPA: true, EEA: true, Currency: "EUR"},
+	"NO": {SEPA: true, EEA: true, Currency: "NOK"},
+	"OM": {Currency: "OMR"},
+	"PF": {Currency: "XPF"},
+	"PK": {Currency: "PKR"},
+	"PL": {SEPA: true, EEA: true, Currency: "PLN"},
+	"PM": {SEPA: true, Currency: "EUR"},
+	"PS": {Currency: "ILS"},
+	"PT": {SEPA: true, EEA: true, Currency: "EUR"},
+	"QA": {Currency: "QAR"},
+	"RE": {SEPA: true, EEA: true, Currency: "EUR"},
+	"RO": {SEPA: true, EEA: true, Currency: "RON"},
+	"RS": {SEPA: true, Currency: "RSD"},
+	"RU": {Currency: "RUB"},
+	"SA": {Currency: "SAR"},
+	"SC": {Currency: "SCR"},
+	"SD": {Currency: "SDG"},
+	"SE": {SEPA: true, EEA: true, Currency: "SEK"},
+	"SI": {SEPA: true, EEA: true, Currency: "EUR"},
+	"SK": {SEPA: true, EEA: true, Currency: "EUR"},
+	"SM": {SEPA: true, Currency: "EUR"},
+	"SN": {Currency: "XOF"},
+	"SO": {Currency: "SOS"},
+	"ST": {Currency: "STN"},
+	"SV": {Currency: "USD"},
+	"TD": {Currency: "XAF"},
+	"TF": {Currency: "EUR"},
+	"TG": {Currency: "XOF"},
+	"TL": {Currency: "USD"},
+	"TN": {Currency: "TND"},
+	"TR": {Currency: "TRY"},
+	"UA": {Currency: "UAH"},
+	"VA": {SEPA: true, Currency: "EUR"},
+	"VG": {Currency: "USD"},
+	"WF": {Currency: "XPF"},
+	"XK": {Currency: "EUR"},
+	"YE": {Currency: "YER"},
+	"YT": {SEPA: true, EEA: true, Currency: "EUR"},
+}
+
+// WithEPCRegister derives the SEPA schemes a bank is reachable by from the EPC register of participants.
+// The bank's BIC is taken from the bank directory, so it requires WithBankDirectory to have an effect.
+func WithEPCRegister(register *epc.Register) Option {
+	return func(svc *Service) error {
+		svc.epcRegister = register
+		return nil
+	}
+}
+
+// annotateSEPA sets the iban's SEPA and EEA membership and currency.
+func annotateSEPA(iban IBAN) IBAN {
+	info := countries[iban.CountryCode]
+	iban.SEPA = info.SEPA
+	iban.EEA = info.EEA
+	iban.Currency = info.Currency
+
+	return iban
+}
+
+// reachableSchemes returns the SEPA schemes the iban's bank participates in, or nil if they are unknown.
+func (svc *Service) reachableSchemes(iban IBAN) []string {
+	if !iban.SEPA || svc.epcRegister == nil || iban.Bank == nil || iban.Bank.BIC == "" {
+		return nil
+	}
+
+	schemes, _ := svc.epcRegister.Lookup(iban.Bank.BIC)
+	return schemes
+}
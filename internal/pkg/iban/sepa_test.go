@@ -0,0 +1,101 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+	"github.com/ymakhloufi/pfc/internal/pkg/epc"
+)
+
+func TestService_Parse_SEPA(t *testing.T) {
+	tests := []struct {
+		name         string
+		ibanStr      string
+		wantSEPA     bool
+		wantEEA      bool
+		wantCurrency string
+	}{
+		{name: "euro area", ibanStr: "DE89370400440532013000", wantSEPA: true, wantEEA: true, wantCurrency: "EUR"},
+		{name: "EEA outside euro area", ibanStr: "NO9386011117947", wantSEPA: true, wantEEA: true, wantCurrency: "NOK"},
+		{name: "SEPA outside EEA", ibanStr: "GB29NWBK60161331926819", wantSEPA: true, wantCurrency: "GBP"},
+		{name: "outside SEPA", ibanStr: "TR330006100519786457841326", wantCurrency: "TRY"},
+		{name: "territory in SEPA", ibanStr: "GF4120041010050500013M02606", wantSEPA: true, wantEEA: true, wantCurrency: "EUR"},
+		{name: "territory outside SEPA", ibanStr: "NC4120041010050500013M02606", wantCurrency: "XPF"},
+		{name: "unsupported country", ibanStr: "XX89370400440532013000"},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSEPA, iban.SEPA)
+			require.Equal(t, tt.wantEEA, iban.EEA)
+			require.Equal(t, tt.wantCurrency, iban.Currency)
+		})
+	}
+}
+
+func TestService_Parse_WithEPCRegister(t *testing.T) {
+	dir := bankdir.NewDirectory([]bankdir.Bank{
+		{CountryCode: "DE", BankCode: "37040044", Name: "Commerzbank", BIC: "COBADEFFXXX"},
+		{CountryCode: "NL", BankCode: "ABNA", Name: "ABN AMRO", BIC: "ABNANL2A"},
+		{CountryCode: "NL", BankCode: "RABO", Name: "Rabobank"},
+		{CountryCode: "TR", BankCode: "00061", Name: "Türkiye İş Bankası", BIC: "ISBKTRIS"},
+	})
+	register := epc.NewRegister([]epc.Participant{
+		{BIC: "COBADEFF", Scheme: epc.SchemeSCT},
+		{BIC: "COBADEFF", Scheme: epc.SchemeSCTInst},
+		{BIC: "COBADEFF", Scheme: epc.SchemeSDDCore},
+		{BIC: "COBADEFF", Scheme: epc.SchemeSDDB2B},
+		{BIC: "ISBKTRIS", Scheme: epc.SchemeSCT},
+	})
+
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    []string
+	}{
+		{
+			name:    "bank in register",
+			ibanStr: "DE89370400440532013000",
+			want:    []string{epc.SchemeSCT, epc.SchemeSCTInst, epc.SchemeSDDCore, epc.SchemeSDDB2B},
+		},
+		{name: "bank not in register", ibanStr: "NL91ABNA0417164300"},
+		{name: "bank without BIC", ibanStr: "NL69RABO0123456789"},
+		{name: "unknown bank", ibanStr: "NL20INGB0001234567"},
+		{name: "country outside SEPA", ibanStr: "TR330006100519786457841326"},
+	}
+
+	svc, err := NewService(WithBankDirectory(dir), WithEPCRegister(register))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, iban.Schemes)
+		})
+	}
+}
+
+func Test_countries_CoverSupportedCountries(t *testing.T) {
+	svc, err := NewService(WithExperimentalCountries())
+	require.NoError(t, err)
+
+	for countryCode := range svc.validators {
+		require.Contains(t, countries, countryCode)
+	}
+	for countryCode := range territories {
+		require.Contains(t, countries, countryCode)
+	}
+}
@@ -1,8 +1,14 @@
 package iban
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"regexp"
+	"time"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/bankdir"
+	"github.com/ymakhloufi/pfc/internal/pkg/epc"
 )
 
 var (
@@ -11,68 +17,129 @@ var (
 	ibanRegexp                 = regexp.MustCompile(`^([A-Z]{2})(\d{2})([A-Z\d]+)$`)
 	ErrIncorrectIbanFormat     = fmt.Errorf("provided string does not satisfy the iban format: %s", ibanRegexp.String())
 	ErrCountryCodeNotSupported = fmt.Errorf("country code is not supported")
+	ErrCountryCodeNotEffective = fmt.Errorf("country code is not supported at the given date")
 	ErrBBANEmpty               = fmt.Errorf("BBAN is empty")
 	ErrCountryCodeEmpty        = fmt.Errorf("country code is empty")
 )
 
 type Service struct {
 	validators map[string]countryValidator
+
+	// accountValidators are applied to the validators once all options are applied,
+	// so they survive a registry being replaced by a later option.
+	accountValidators map[string]func(bban string) error
+
+	// experimental enables the countries that are not part of the registry, once all options are applied.
+	experimental bool
+
+	// bankDirectory optionally derives the bank of an IBAN from its bank and branch code.
+	bankDirectory *bankdir.Directory
+
+	// epcRegister optionally derives the SEPA schemes the bank of an IBAN is reachable by.
+	epcRegister *epc.Register
+
+	// level configures how deep Validate, ValidateAt and ValidateAll validate IBANs.
+	level Level
 }
 
-func NewService() *Service {
-	return &Service{
-		validators: countryValidators,
+// Option configures a Service.
+type Option func(*Service) error
+
+// WithRegistry makes the Service use the SWIFT IBAN Registry export read from r instead of the embedded one.
+func WithRegistry(r io.Reader) Option {
+	return func(svc *Service) error {
+		validators, err := loadRegistry(r)
+		if err != nil {
+			return err
+		}
+
+		svc.validators = validators
+		return nil
 	}
 }
 
-func (svc *Service) Parse(ibanStr string) (IBAN, error) {
-	matches := ibanRegexp.FindStringSubmatch(ibanStr)
-	if matches == nil || len(matches) != 4 {
-		return IBAN{}, ErrIncorrectIbanFormat
+// NewService creates a new Service, using the embedded SWIFT IBAN Registry unless configured otherwise.
+func NewService(opts ...Option) (*Service, error) {
+	validators, err := loadRegistry(bytes.NewReader(defaultRegistry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded registry: %w", err)
 	}
 
-	countryCode, checkDigits, bban := matches[1], matches[2], matches[3]
-
-	return IBAN{
-		CountryCode: countryCode,
-		CheckDigits: checkDigits,
-		BBAN:        bban,
-	}, nil
-}
+	svc := &Service{
+		validators:        validators,
+		accountValidators: map[string]func(bban string) error{},
+		level:             LevelNational,
+	}
 
-// Validate validates the iban's format and checks the check-digits.
-func (svc *Service) Validate(i IBAN) error {
-	if i.CountryCode == "" {
-		return ErrCountryCodeEmpty
+	for _, opt := range opts {
+		if err = opt(svc); err != nil {
+			return nil, fmt.Errorf("failed to apply service option: %w", err)
+		}
 	}
-	if i.BBAN == "" {
-		return ErrBBANEmpty
+
+	if svc.level == LevelDirectory && svc.bankDirectory == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBankDirectoryRequired, svc.level)
 	}
 
-	validator, ok := svc.validators[i.CountryCode]
-	if !ok {
-		return ErrCountryCodeNotSupported
+	if svc.experimental {
+		if err = svc.addExperimentalCountries(); err != nil {
+			return nil, err
+		}
 	}
 
-	err := validator.ValidateIbanLength(i)
-	if err != nil {
-		return fmt.Errorf("iban length validation error: %w", err)
+	for countryCode, accountValidator := range svc.accountValidators {
+		validator, ok := svc.validators[countryCode]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot validate accounts of %s", ErrCountryCodeNotSupported, countryCode)
+		}
+
+		validator.AccountValidator = accountValidator
+		svc.validators[countryCode] = validator
 	}
 
-	err = validator.ValidateIbanChecksum(i)
-	if err != nil {
-		return fmt.Errorf("iban checksum validation error: %w", err)
+	return svc, nil
+}
+
+// Parse parses the iban string and annotates it with the rules of its country that are in effect today.
+func (svc *Service) Parse(ibanStr string) (IBAN, error) {
+	return svc.ParseAt(ibanStr, time.Now())
+}
+
+// ParseAt parses the iban string and annotates it with the rules of its country that were in effect on the day of t,
+// e.g. to split the BBAN of a historical IBAN into the components of the format it had back then.
+func (svc *Service) ParseAt(ibanStr string, t time.Time) (IBAN, error) {
+	matches := ibanRegexp.FindStringSubmatch(ibanStr)
+	if matches == nil || len(matches) != 4 {
+		return IBAN{}, formatError(ibanStr)
 	}
 
-	err = validator.ValidateBbanFormat(i)
-	if err != nil {
-		return fmt.Errorf("bban format validation error: %w", err)
+	countryCode, checkDigits, bban := matches[1], matches[2], matches[3]
+	iban := IBAN{
+		CountryCode: countryCode,
+		CheckDigits: checkDigits,
+		BBAN:        bban,
 	}
 
-	err = validator.ValidateBbanChecksum(i)
-	if err != nil {
-		return fmt.Errorf("bban checksum validation error: %w", err)
+	validator, ok := svc.validator(countryCode)
+	if ok {
+		validator, ok = validator.At(t)
+	}
+	if ok {
+		iban = validator.Annotate(iban)
+		iban.Bank = svc.lookupBank(iban)
+		iban.Schemes = svc.reachableSchemes(iban)
 	}
 
-	return nil
+	return iban, nil
+}
+
+// Validate validates the iban at the Service's level, using the rules that are in effect today.
+func (svc *Service) Validate(i IBAN) error {
+	return svc.ValidateAt(i, time.Now())
+}
+
+// ValidateAt validates the iban at the Service's level, using the rules that were in effect
+// on the day of t, e.g. to find out whether the IBAN of a historical payment was valid on its transaction date.
+func (svc *Service) ValidateAt(i IBAN, t time.Time) error {
+	return svc.ValidateAll(i, t).Err()
 }
@@ -2,8 +2,9 @@ package iban
 
 import (
 	"reflect"
-	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -53,7 +54,7 @@ func TestService_Parse(t *testing.T) {
 			t.Parallel()
 
 			got, err := svc.Parse(tt.ibanStr)
-			require.Equal(t, tt.wantErr, err)
+			require.ErrorIs(t, err, tt.wantErr)
 			if err != nil && !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Parse() got = %v, want %v", got, tt.want)
 			}
@@ -77,9 +78,9 @@ func TestService_Validate(t *testing.T) {
 			},
 			validators: map[string]countryValidator{
 				"NL": {
-					CountryCode: "NL",
-					Length:      18,
-					BBANRegex:   regexp.MustCompile(`^[\dA-Z]+$`),
+					CountryCode:   "NL",
+					Length:        18,
+					BBANStructure: mustParseBBANStructure("30c"),
 				},
 			},
 		},
@@ -119,9 +120,9 @@ func TestService_Validate(t *testing.T) {
 			},
 			validators: map[string]countryValidator{
 				"NL": {
-					CountryCode: "NL",
-					Length:      5,
-					BBANRegex:   regexp.MustCompile(`^[\dA-Z]+$`),
+					CountryCode:   "NL",
+					Length:        5,
+					BBANStructure: mustParseBBANStructure("30c"),
 				},
 			},
 			wantErr: ErrIncorrectLength,
@@ -135,9 +136,9 @@ func TestService_Validate(t *testing.T) {
 			},
 			validators: map[string]countryValidator{
 				"GB": {
-					CountryCode: "GB",
-					Length:      22,
-					BBANRegex:   regexp.MustCompile(`^[\dA-Z]+$`),
+					CountryCode:   "GB",
+					Length:        22,
+					BBANStructure: mustParseBBANStructure("30c"),
 				},
 			},
 			wantErr: ErrIncorrectIBANChecksum,
@@ -151,9 +152,9 @@ func TestService_Validate(t *testing.T) {
 			},
 			validators: map[string]countryValidator{
 				"NL": {
-					CountryCode: "NL",
-					Length:      18,
-					BBANRegex:   regexp.MustCompile(`^[\d]+$`), // only accepts digits
+					CountryCode:   "NL",
+					Length:        18,
+					BBANStructure: mustParseBBANStructure("30n"), // only accepts digits
 				},
 			},
 			wantErr: ErrIncorrectBBANFormat,
@@ -167,11 +168,12 @@ func TestService_Validate(t *testing.T) {
 			},
 			validators: map[string]countryValidator{
 				"NL": {
-					CountryCode: "NL",
-					Length:      18,
-					BBANRegex:   regexp.MustCompile(`^[\dA-Z]+$`),
-					BBANChecksumFunc: func(s string) bool {
-						return false
+					CountryCode:   "NL",
+					Length:        18,
+					BBANStructure: mustParseBBANStructure("30c"),
+					BBANChecksum: bbanChecksum{
+						Name: "never valid",
+						Func: func(s string) bool { return false },
 					},
 				},
 			},
@@ -188,3 +190,198 @@ func TestService_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Parse_BBANComponents(t *testing.T) {
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    IBAN
+	}{
+		{
+			name:    "DE has bank code and account number",
+			ibanStr: "DE89370400440532013000",
+			want: IBAN{
+				CountryCode:   "DE",
+				CheckDigits:   "89",
+				BBAN:          "370400440532013000",
+				SEPA:          true,
+				EEA:           true,
+				Currency:      "EUR",
+				BankCode:      "37040044",
+				AccountNumber: "0532013000",
+			},
+		},
+		{
+			name:    "GB has bank code, sort code and account number",
+			ibanStr: "GB29NWBK60161331926819",
+			want: IBAN{
+				CountryCode:   "GB",
+				CheckDigits:   "29",
+				BBAN:          "NWBK60161331926819",
+				SEPA:          true,
+				Currency:      "GBP",
+				BankCode:      "NWBK",
+				BranchCode:    "601613",
+				AccountNumber: "31926819",
+			},
+		},
+		{
+			name:    "IT has leading national check digit",
+			ibanStr: "IT60X0542811101000000123456",
+			want: IBAN{
+				CountryCode:         "IT",
+				CheckDigits:         "60",
+				BBAN:                "X0542811101000000123456",
+				SEPA:                true,
+				EEA:                 true,
+				Currency:            "EUR",
+				BankCode:            "05428",
+				BranchCode:          "11101",
+				AccountNumber:       "000000123456",
+				NationalCheckDigits: "X",
+			},
+		},
+		{
+			name:    "BBAN with wrong length is not split",
+			ibanStr: "DE8937040044053201300",
+			want: IBAN{
+				CountryCode: "DE",
+				CheckDigits: "89",
+				BBAN:        "37040044053201300",
+				SEPA:        true,
+				EEA:         true,
+				Currency:    "EUR",
+			},
+		},
+		{
+			name:    "unsupported country is not split",
+			ibanStr: "XX89370400440532013000",
+			want: IBAN{
+				CountryCode: "XX",
+				CheckDigits: "89",
+				BBAN:        "370400440532013000",
+			},
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ValidateAt(t *testing.T) {
+	pinned := `{"countries": [
+		{"country_code": "GB", "iban_length": 20, "bban_structure": "4!a6!n6!n", "effective_from": "2010-01-01", "effective_until": "2019-12-31"},
+		{"country_code": "GB", "iban_length": 22, "bban_structure": "4!a6!n8!n", "effective_from": "2020-01-01"}
+	]}`
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantErr error
+	}{
+		{name: "current rules", at: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "current rules until the end of the day", at: time.Date(2020, 1, 1, 23, 59, 59, 0, time.UTC)},
+		{name: "earlier rules", at: time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC), wantErr: ErrIncorrectLength},
+		{name: "no rules in effect", at: time.Date(2009, 12, 31, 0, 0, 0, 0, time.UTC), wantErr: ErrCountryCodeNotEffective},
+	}
+
+	svc, err := NewService(WithRegistry(strings.NewReader(pinned)))
+	require.NoError(t, err)
+
+	iban, err := svc.Parse("GB29NWBK60161331926819")
+	require.NoError(t, err)
+	require.NoError(t, svc.Validate(iban))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			require.ErrorIs(t, svc.ValidateAt(iban, tt.at), tt.wantErr)
+		})
+	}
+}
+
+func TestService_ParseAt(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		ibanStr       string
+		at            time.Time
+		wantBankCode  string
+		wantAccountNo string
+	}{
+		{
+			name:          "current format",
+			ibanStr:       "CR05015202001026284066",
+			at:            time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantBankCode:  "0152",
+			wantAccountNo: "02001026284066",
+		},
+		{
+			name:          "earlier format",
+			ibanStr:       "CR0515202001026284066",
+			at:            time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantBankCode:  "152",
+			wantAccountNo: "02001026284066",
+		},
+		{
+			name:    "before the country joined",
+			ibanStr: "UA213223130000026007233566001",
+			at:      time.Date(2015, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			iban, err := svc.ParseAt(tt.ibanStr, tt.at)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBankCode, iban.BankCode)
+			require.Equal(t, tt.wantAccountNo, iban.AccountNumber)
+		})
+	}
+}
+
+func TestService_ValidateAt_RegistryRevisions(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		ibanStr string
+		at      time.Time
+		wantErr error
+	}{
+		{name: "CR with 22 characters today", ibanStr: "CR05015202001026284066", at: time.Now()},
+		{name: "CR with 21 characters today", ibanStr: "CR0515202001026284066", at: time.Now(), wantErr: ErrIncorrectLength},
+		{name: "CR with 21 characters before 2017", ibanStr: "CR0515202001026284066", at: time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "CR with 22 characters before 2017", ibanStr: "CR05015202001026284066", at: time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC), wantErr: ErrIncorrectLength},
+		{name: "UA after it joined", ibanStr: "UA213223130000026007233566001", at: time.Date(2016, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "UA before it joined", ibanStr: "UA213223130000026007233566001", at: time.Date(2016, 1, 31, 0, 0, 0, 0, time.UTC), wantErr: ErrCountryCodeNotEffective},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			iban, err := svc.ParseAt(tt.ibanStr, tt.at)
+			require.NoError(t, err)
+			require.ErrorIs(t, svc.ValidateAt(iban, tt.at), tt.wantErr)
+		})
+	}
+}
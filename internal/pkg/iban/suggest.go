@@ -0,0 +1,109 @@
+package iban
+
+import (
+	"errors"
+	"sort"
+)
+
+// Kinds of typos a Suggestion corrects, ordered from most to least likely.
+const (
+	SuggestionOCRConfusable = "ocr_confusable" // a character read as a similar looking one, e.g. O instead of 0
+	SuggestionTransposition = "transposition"  // two adjacent characters swapped
+	SuggestionSubstitution  = "substitution"   // a single character mistyped
+)
+
+// ocrConfusables maps characters to the ones they are commonly confused with when read by humans or OCR.
+var ocrConfusables = map[byte]byte{'O': '0', '0': 'O', 'I': '1', '1': 'I', 'S': '5', '5': 'S', 'B': '8', '8': 'B'}
+
+const alphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// swagger:model
+type Suggestion struct {
+	IBAN string `json:"iban"`
+	Kind string `json:"kind"`
+	// Position is the 0-based position of the corrected character, or of the first of two transposed characters.
+	Position int `json:"position"`
+}
+
+// Suggest searches for IBANs the iban was probably mistyped from, i.e. that differ by a single OCR confusable,
+// a transposition of two adjacent characters or a single substituted character, and that pass the length,
+// IBAN checksum and BBAN format validation. The country code is never changed.
+// Suggestions that also pass the national BBAN checksum are ranked first, followed by the more likely kinds of typos.
+func (svc *Service) Suggest(i IBAN) []Suggestion {
+	validator, ok := svc.validator(i.CountryCode)
+	if !ok {
+		return nil
+	}
+
+	type candidate struct {
+		Suggestion
+		passesBBANChecksum bool
+	}
+
+	ibanStr := i.String()
+	seen := map[string]bool{ibanStr: true}
+	var candidates []candidate
+
+	try := func(s []byte, kind string, position int) {
+		str := string(s)
+		if seen[str] {
+			return
+		}
+		seen[str] = true
+
+		iban, err := svc.Parse(str)
+		if err != nil ||
+			validator.ValidateIbanLength(iban) != nil ||
+			validator.ValidateIbanChecksum(iban) != nil ||
+			validator.ValidateBbanFormat(iban) != nil {
+			return
+		}
+
+		candidates = append(candidates, candidate{
+			Suggestion:         Suggestion{IBAN: str, Kind: kind, Position: position},
+			passesBBANChecksum: passesBBANChecksum(validator, iban),
+		})
+	}
+
+	// the kinds are tried in order of their likelihood, so a candidate keeps its most likely kind
+	for pos := 2; pos < len(ibanStr); pos++ {
+		if confusable, ok := ocrConfusables[ibanStr[pos]]; ok {
+			s := []byte(ibanStr)
+			s[pos] = confusable
+			try(s, SuggestionOCRConfusable, pos)
+		}
+	}
+
+	for pos := 2; pos < len(ibanStr)-1; pos++ {
+		s := []byte(ibanStr)
+		s[pos], s[pos+1] = s[pos+1], s[pos]
+		try(s, SuggestionTransposition, pos)
+	}
+
+	for pos := 2; pos < len(ibanStr); pos++ {
+		for j := 0; j < len(alphanumeric); j++ {
+			s := []byte(ibanStr)
+			s[pos] = alphanumeric[j]
+			try(s, SuggestionSubstitution, pos)
+		}
+	}
+
+	// stable sort keeps the order of the kinds and positions within candidates passing the BBAN checksum (or not)
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].passesBBANChecksum && !candidates[b].passesBBANChecksum
+	})
+
+	suggestions := make([]Suggestion, 0, len(candidates))
+	for _, c := range candidates {
+		suggestions = append(suggestions, c.Suggestion)
+	}
+
+	return suggestions
+}
+
+// passesBBANChecksum reports whether the iban's national check digits and account number are valid, or the account
+// number cannot be checked.
+func passesBBANChecksum(validator countryValidator, iban IBAN) bool {
+	err := validator.ValidateBbanChecksum(iban)
+	return err == nil || errors.Is(err, ErrAccountCheckNotSupported)
+}
@@ -0,0 +1,78 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Suggest(t *testing.T) {
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    Suggestion
+	}{
+		{
+			name:    "OCR confusable",
+			ibanStr: "GB29NWBK6O161331926819",
+			want:    Suggestion{IBAN: "GB29NWBK60161331926819", Kind: SuggestionOCRConfusable, Position: 9},
+		},
+		{
+			name:    "transposition",
+			ibanStr: "GB29NWBK60161331926891",
+			want:    Suggestion{IBAN: "GB29NWBK60161331926819", Kind: SuggestionTransposition, Position: 20},
+		},
+		{
+			name:    "substitution",
+			ibanStr: "DE89370400440532013001",
+			want:    Suggestion{IBAN: "DE89370400440532013000", Kind: SuggestionSubstitution, Position: 21},
+		},
+		{
+			name:    "substitution of check digits",
+			ibanStr: "DE88370400440532013000",
+			want:    Suggestion{IBAN: "DE89370400440532013000", Kind: SuggestionSubstitution, Position: 3},
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.ErrorIs(t, svc.Validate(iban), ErrIncorrectIBANChecksum)
+
+			got := svc.Suggest(iban)
+			require.Contains(t, got, tt.want)
+			for _, s := range got {
+				i, err := svc.Parse(s.IBAN)
+				require.NoError(t, err)
+				require.NotErrorIs(t, svc.Validate(i), ErrIncorrectIBANChecksum)
+			}
+		})
+	}
+}
+
+func TestService_Suggest_Ranking(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	// FR has a national checksum (RIB key), so only few candidates pass it
+	iban, err := svc.Parse("FR1420041010050500O13M02606")
+	require.NoError(t, err)
+
+	got := svc.Suggest(iban)
+	require.NotEmpty(t, got)
+	require.Equal(t, Suggestion{IBAN: "FR1420041010050500013M02606", Kind: SuggestionOCRConfusable, Position: 18}, got[0])
+}
+
+func TestService_Suggest_UnsupportedCountry(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	require.Empty(t, svc.Suggest(IBAN{CountryCode: "US", CheckDigits: "00", BBAN: "123"}))
+}
@@ -0,0 +1,51 @@
+package iban
+
+// territories maps the country codes of territories to the country whose IBAN format they follow.
+// The SWIFT IBAN Registry lists them as part of their governing country, but their IBANs may carry their own prefix.
+// A territory listed in the registry itself is validated by its own rules.
+var territories = map[string]string{
+	"GF": "FR", // French Guiana
+	"GP": "FR", // Guadeloupe
+	"MQ": "FR", // Martinique
+	"RE": "FR", // Réunion
+	"YT": "FR", // Mayotte
+	"NC": "FR", // New Caledonia
+	"PF": "FR", // French Polynesia
+	"PM": "FR", // Saint Pierre and Miquelon
+	"TF": "FR", // French Southern Territories
+	"WF": "FR", // Wallis and Futuna
+	"BL": "FR", // Saint Barthélemy
+	"MF": "FR", // Saint Martin
+	"AX": "FI", // Åland Islands
+	"JE": "GB", // Jersey
+	"GG": "GB", // Guernsey
+	"IM": "GB", // Isle of Man
+}
+
+// validator returns the validator for the country code. Territories get the validator of their governing country.
+func (svc *Service) validator(countryCode string) (countryValidator, bool) {
+	if validator, ok := svc.validators[countryCode]; ok {
+		return validator, true
+	}
+
+	governingCountryCode, ok := territories[countryCode]
+	if !ok {
+		return countryValidator{}, false
+	}
+
+	validator, ok := svc.validators[governingCountryCode]
+	if !ok {
+		return countryValidator{}, false
+	}
+
+	validator.CountryCode = countryCode
+	validator.GoverningCountryCode = governingCountryCode
+	return validator, true
+}
+
+// SupportsCountry reports whether there are IBAN rules for the country, including territories
+// and enabled experimental countries.
+func (svc *Service) SupportsCountry(countryCode string) bool {
+	_, ok := svc.validator(countryCode)
+	return ok
+}
@@ -0,0 +1,77 @@
+package iban
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Validate_Territories(t *testing.T) {
+	tests := []struct {
+		name                     string
+		ibanStr                  string
+		wantGoverningCountryCode string
+		wantErr                  error
+	}{
+		{name: "French Guiana", ibanStr: "GF4120041010050500013M02606", wantGoverningCountryCode: "FR"},
+		{name: "Réunion", ibanStr: "RE4220041010050500013M02606", wantGoverningCountryCode: "FR"},
+		{name: "Åland Islands", ibanStr: "AX2112345600000785", wantGoverningCountryCode: "FI"},
+		{name: "Jersey", ibanStr: "JE90NWBK60161331926819", wantGoverningCountryCode: "GB"},
+		{name: "Isle of Man", ibanStr: "IM75NWBK60161331926819", wantGoverningCountryCode: "GB"},
+		{name: "governing country", ibanStr: "FR1420041010050500013M02606"},
+		{
+			name:                     "territory uses national checksum of governing country",
+			ibanStr:                  "GF1420041010050500013M02607",
+			wantGoverningCountryCode: "FR",
+			wantErr:                  ErrIncorrectBBANChecksum,
+		},
+		{
+			name:                     "check digits are computed with the territory's country code",
+			ibanStr:                  "GF1420041010050500013M02606",
+			wantGoverningCountryCode: "FR",
+			wantErr:                  ErrIncorrectIBANChecksum,
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantGoverningCountryCode, iban.GoverningCountryCode)
+			require.ErrorIs(t, svc.Validate(iban), tt.wantErr)
+		})
+	}
+}
+
+func TestService_Validate_TerritoryWithoutGoverningCountry(t *testing.T) {
+	pinned := `{"countries": [{"country_code": "NL", "iban_length": 18, "bban_structure": "4!a10!n"}]}`
+
+	svc, err := NewService(WithRegistry(strings.NewReader(pinned)))
+	require.NoError(t, err)
+
+	iban, err := svc.Parse("JE90NWBK60161331926819")
+	require.NoError(t, err)
+	require.Empty(t, iban.GoverningCountryCode)
+	require.ErrorIs(t, svc.Validate(iban), ErrCountryCodeNotSupported)
+}
+
+func TestService_SupportsCountry(t *testing.T) {
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	require.True(t, svc.SupportsCountry("DE"))
+	require.True(t, svc.SupportsCountry("GF"))
+	require.False(t, svc.SupportsCountry("US"))
+	require.False(t, svc.SupportsCountry("MG"))
+
+	svc, err = NewService(WithExperimentalCountries())
+	require.NoError(t, err)
+	require.True(t, svc.SupportsCountry("MG"))
+}
@@ -0,0 +1,38 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/ukmodulus"
+)
+
+// WithUKModulusChecker validates the sort code and account number of GB IBANs using the UK modulus checking rules,
+// as published by Pay.UK in the modulus weight table and the sort code substitution table.
+func WithUKModulusChecker(checker *ukmodulus.Checker) Option {
+	return func(svc *Service) error {
+		svc.accountValidators["GB"] = ukAccountValidator(checker)
+		return nil
+	}
+}
+
+// ukAccountValidator validates the sort code and account number of a UK BBAN
+// (4 letters bank code, 6 digits sort code, 8 digits account number).
+func ukAccountValidator(checker *ukmodulus.Checker) func(bban string) error {
+	return func(bban string) error {
+		if len(bban) != 18 {
+			return fmt.Errorf("%w: expected 18 characters", ErrIncorrectBBANFormat)
+		}
+
+		sortCode, accountNumber := bban[4:10], bban[10:]
+		err := checker.Validate(sortCode, accountNumber)
+		switch {
+		case errors.Is(err, ukmodulus.ErrModulusCheckFailed):
+			return fmt.Errorf("%w: %s", ErrIncorrectSortCodeAccount, err)
+		case err != nil:
+			return fmt.Errorf("%w: %s", ErrIncorrectBBANFormat, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package iban
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ymakhloufi/pfc/internal/pkg/ukmodulus"
+)
+
+func TestService_Validate_WithUKModulusChecker(t *testing.T) {
+	checker := ukmodulus.NewChecker([]ukmodulus.Rule{
+		{
+			From:    "601613",
+			To:      "601613",
+			Method:  ukmodulus.MethodMod11,
+			Weights: [14]int{0, 0, 0, 0, 0, 0, 8, 7, 6, 5, 4, 3, 2, 1},
+		},
+	}, nil)
+
+	tests := []struct {
+		name    string
+		ibanStr string
+		wantErr error
+	}{
+		{
+			name:    "valid sort code and account number",
+			ibanStr: "GB29NWBK60161331926819",
+		},
+		{
+			name:    "invalid sort code and account number with valid IBAN checksum",
+			ibanStr: "GB78NWBK60161331926810",
+			wantErr: ErrIncorrectSortCodeAccount,
+		},
+		{
+			name:    "sort code without modulus rules is not rejected",
+			ibanStr: "GB33BUKB20201555555555",
+		},
+	}
+
+	svc, err := NewService(WithUKModulusChecker(checker))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+			require.ErrorIs(t, svc.Validate(iban), tt.wantErr)
+		})
+	}
+}
+
+func TestNewService_WithUKModulusChecker_WithoutGB(t *testing.T) {
+	pinned := `{"countries": [{"country_code": "DE", "iban_length": 22, "bban_structure": "8!n10!n"}]}`
+
+	_, err := NewService(WithUKModulusChecker(ukmodulus.NewChecker(nil, nil)), WithRegistry(strings.NewReader(pinned)))
+	require.ErrorIs(t, err, ErrCountryCodeNotSupported)
+}
@@ -0,0 +1,166 @@
+package iban
+
+import "errors"
+
+// Stable codes of the ValidationError, for clients to act upon instead of the error message.
+const (
+	CodeIncorrectFormat          = "incorrect_format"
+	CodeCountryCodeEmpty         = "country_code_empty"
+	CodeBBANEmpty                = "bban_empty"
+	CodeCountryCodeNotSupported  = "country_code_not_supported"
+	CodeCountryCodeNotEffective  = "country_code_not_effective"
+	CodeIncorrectLength          = "incorrect_length"
+	CodeIncorrectIBANChecksum    = "incorrect_iban_checksum"
+	CodeIncorrectBBANFormat      = "incorrect_bban_format"
+	CodeIncorrectBBANChecksum    = "incorrect_bban_checksum"
+	CodeUnknownBankCode          = "unknown_bank_code"
+	CodeIncorrectAccountNumber   = "incorrect_account_number"
+	CodeIncorrectSortCodeAccount = "incorrect_sort_code_account"
+	CodeInvalid                  = "invalid" // any other error
+)
+
+// Components of an IBAN a ValidationError can refer to.
+const (
+	ComponentIBAN                = "iban"
+	ComponentCountryCode         = "country_code"
+	ComponentCheckDigits         = "check_digits"
+	ComponentBBAN                = "bban"
+	ComponentBankCode            = "bank_code"
+	ComponentBranchCode          = "branch_code"
+	ComponentAccountNumber       = "account_number"
+	ComponentNationalCheckDigits = "national_check_digits"
+)
+
+// errorCodes maps the sentinel errors to their codes.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{err: ErrIncorrectIbanFormat, code: CodeIncorrectFormat},
+	{err: ErrCountryCodeEmpty, code: CodeCountryCodeEmpty},
+	{err: ErrBBANEmpty, code: CodeBBANEmpty},
+	{err: ErrCountryCodeNotSupported, code: CodeCountryCodeNotSupported},
+	{err: ErrCountryCodeNotEffective, code: CodeCountryCodeNotEffective},
+	{err: ErrIncorrectLength, code: CodeIncorrectLength},
+	{err: ErrIncorrectIBANChecksum, code: CodeIncorrectIBANChecksum},
+	{err: ErrIncorrectBBANFormat, code: CodeIncorrectBBANFormat},
+	{err: ErrIncorrectBBANChecksum, code: CodeIncorrectBBANChecksum},
+	{err: ErrUnknownBankCode, code: CodeUnknownBankCode},
+	{err: ErrIncorrectAccountNumber, code: CodeIncorrectAccountNumber},
+	{err: ErrIncorrectSortCodeAccount, code: CodeIncorrectSortCodeAccount},
+}
+
+// ValidationError describes why an IBAN is invalid in a machine-readable way. It wraps the sentinel error,
+// e.g. ErrIncorrectBBANFormat, so it can be checked with errors.Is, and be retrieved with errors.As.
+//
+// swagger:model
+type ValidationError struct {
+	Code string `json:"code"`
+	// Component is the part of the IBAN the error refers to, e.g. "check_digits" or "account_number".
+	Component string `json:"component,omitempty"`
+	// Offset is the 0-based position of the first offending character within the IBAN's electronic form,
+	// only set if a single character can be blamed.
+	Offset *int `json:"offset,omitempty"`
+	// ExpectedLength and ActualLength are the lengths of the IBAN, only set for incorrect lengths.
+	ExpectedLength int `json:"expected_length,omitempty"`
+	ActualLength   int `json:"actual_length,omitempty"`
+
+	err error
+}
+
+// newValidationError creates the ValidationError for err, with the offset of the offending character, -1 if unknown.
+func newValidationError(err error, component string, offset int) *ValidationError {
+	e := &ValidationError{Code: CodeInvalid, Component: component, err: err}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			e.Code = c.code
+			break
+		}
+	}
+
+	if offset >= 0 {
+		e.Offset = &offset
+	}
+
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// formatError returns the ValidationError for an iban string that does not satisfy the iban format,
+// blaming its first character that is not allowed at its position.
+func formatError(ibanStr string) *ValidationError {
+	for i := 0; i < len(ibanStr); i++ {
+		c := ibanStr[i]
+		switch {
+		case i < 2 && !isUpper(c):
+			return newValidationError(ErrIncorrectIbanFormat, ComponentCountryCode, i)
+		case i >= 2 && i < 4 && !isDigit(c):
+			return newValidationError(ErrIncorrectIbanFormat, ComponentCheckDigits, i)
+		case i >= 4 && !isUpper(c) && !isDigit(c):
+			return newValidationError(ErrIncorrectIbanFormat, ComponentBBAN, i)
+		}
+	}
+
+	// all characters are allowed, so the string is too short
+	return newValidationError(ErrIncorrectIbanFormat, ComponentIBAN, -1)
+}
+
+// locate returns the ValidationError for the error of validating the iban against the country's rules,
+// blaming the component and character that caused it.
+func (c countryValidator) locate(iban IBAN, err error) *ValidationError {
+	const bbanOffset = 4
+
+	switch {
+	case errors.Is(err, ErrIncorrectLength):
+		// only the characters beyond the expected length can be blamed, missing ones can't
+		offset, length := -1, len(iban.String())
+		if length > c.Length {
+			offset = c.Length
+		}
+		e := newValidationError(err, ComponentIBAN, offset)
+		e.ExpectedLength, e.ActualLength = c.Length, length
+		return e
+	case errors.Is(err, ErrIncorrectIBANChecksum):
+		return newValidationError(err, ComponentCheckDigits, 2)
+	case errors.Is(err, ErrIncorrectBBANFormat):
+		pos := c.BBANStructure.FirstMismatch(iban.BBAN)
+		if pos < 0 {
+			return newValidationError(err, ComponentBBAN, -1)
+		}
+		return newValidationError(err, c.componentAt(pos), bbanOffset+pos)
+	case errors.Is(err, ErrUnknownBankCode) && c.BankCode.End > 0:
+		return newValidationError(err, ComponentBankCode, bbanOffset+c.BankCode.Start)
+	case errors.Is(err, ErrIncorrectAccountNumber), errors.Is(err, ErrIncorrectSortCodeAccount):
+		return newValidationError(err, ComponentAccountNumber, -1)
+	case errors.Is(err, ErrIncorrectBBANChecksum) && c.NationalCheckDigits.End > 0:
+		return newValidationError(err, ComponentNationalCheckDigits, bbanOffset+c.NationalCheckDigits.Start)
+	default:
+		return newValidationError(err, ComponentBBAN, -1)
+	}
+}
+
+// componentAt returns the BBAN component at the position within the BBAN.
+func (c countryValidator) componentAt(pos int) string {
+	for _, component := range []struct {
+		name string
+		r    bbanRange
+	}{
+		{name: ComponentBankCode, r: c.BankCode},
+		{name: ComponentBranchCode, r: c.BranchCode},
+		{name: ComponentAccountNumber, r: c.AccountNumber},
+		{name: ComponentNationalCheckDigits, r: c.NationalCheckDigits},
+	} {
+		if pos >= component.r.Start && pos < component.r.End {
+			return component.name
+		}
+	}
+
+	return ComponentBBAN
+}
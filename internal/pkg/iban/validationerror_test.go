@@ -0,0 +1,115 @@
+package iban
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Validate_ValidationError(t *testing.T) {
+	offset := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		ibanStr string
+		want    ValidationError
+		wantErr error
+	}{
+		{
+			name:    "too long",
+			ibanStr: "DE8937040044053201300012",
+			want:    ValidationError{Code: CodeIncorrectLength, Component: ComponentIBAN, Offset: offset(22), ExpectedLength: 22, ActualLength: 24},
+			wantErr: ErrIncorrectLength,
+		},
+		{
+			name:    "too short",
+			ibanStr: "DE8937040044053201300",
+			want:    ValidationError{Code: CodeIncorrectLength, Component: ComponentIBAN, ExpectedLength: 22, ActualLength: 21},
+			wantErr: ErrIncorrectLength,
+		},
+		{
+			name:    "incorrect checksum",
+			ibanStr: "DE88370400440532013000",
+			want:    ValidationError{Code: CodeIncorrectIBANChecksum, Component: ComponentCheckDigits, Offset: offset(2)},
+			wantErr: ErrIncorrectIBANChecksum,
+		},
+		{
+			name:    "letter in numeric account number",
+			ibanStr: "GB57NWBK6016133192681A",
+			want:    ValidationError{Code: CodeIncorrectBBANFormat, Component: ComponentAccountNumber, Offset: offset(21)},
+			wantErr: ErrIncorrectBBANFormat,
+		},
+		{
+			name:    "incorrect national check digits",
+			ibanStr: "FR8420041010050500013M02607",
+			want:    ValidationError{Code: CodeIncorrectBBANChecksum, Component: ComponentNationalCheckDigits, Offset: offset(25)},
+			wantErr: ErrIncorrectBBANChecksum,
+		},
+		{
+			name:    "unsupported country",
+			ibanStr: "XX89370400440532013000",
+			want:    ValidationError{Code: CodeCountryCodeNotSupported, Component: ComponentCountryCode, Offset: offset(0)},
+			wantErr: ErrCountryCodeNotSupported,
+		},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			iban, err := svc.Parse(tt.ibanStr)
+			require.NoError(t, err)
+
+			err = svc.Validate(iban)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			require.Equal(t, tt.want.Code, validationErr.Code)
+			require.Equal(t, tt.want.Component, validationErr.Component)
+			require.Equal(t, tt.want.Offset, validationErr.Offset)
+			require.Equal(t, tt.want.ExpectedLength, validationErr.ExpectedLength)
+			require.Equal(t, tt.want.ActualLength, validationErr.ActualLength)
+		})
+	}
+}
+
+func TestService_Parse_ValidationError(t *testing.T) {
+	offset := func(i int) *int { return &i }
+
+	tests := []struct {
+		name          string
+		ibanStr       string
+		wantComponent string
+		wantOffset    *int
+	}{
+		{name: "digit in country code", ibanStr: "D189370400440532013000", wantComponent: ComponentCountryCode, wantOffset: offset(1)},
+		{name: "letter in check digits", ibanStr: "DE8X370400440532013000", wantComponent: ComponentCheckDigits, wantOffset: offset(3)},
+		{name: "lower case letter in BBAN", ibanStr: "GB29nWBK60161331926819", wantComponent: ComponentBBAN, wantOffset: offset(4)},
+		{name: "missing BBAN", ibanStr: "DE89", wantComponent: ComponentIBAN},
+	}
+
+	svc, err := NewService()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			_, err := svc.Parse(tt.ibanStr)
+			require.ErrorIs(t, err, ErrIncorrectIbanFormat)
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			require.Equal(t, CodeIncorrectFormat, validationErr.Code)
+			require.Equal(t, tt.wantComponent, validationErr.Component)
+			require.Equal(t, tt.wantOffset, validationErr.Offset)
+		})
+	}
+}
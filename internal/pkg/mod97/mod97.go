@@ -0,0 +1,73 @@
+// Package mod97 implements the ISO 7064 mod 97-10 arithmetic shared by the IBAN check digits (ISO 13616)
+// and the RF creditor reference (ISO 11649).
+package mod97
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Remainder calculates the mod97 of very large string-represented number (too large to fit into uint64)
+//
+//	We use Horner's Method for this (https://en.wikipedia.org/wiki/Horner%27s_method)
+func Remainder(s string) uint {
+	var remainder uint
+	for _, r := range s {
+		remainder = (remainder * 10) + uint(r-'0')
+		remainder %= 97
+	}
+	return remainder
+}
+
+// convertLetterToInt converts a letter to its corresponding number. (A = 10, B = 11, ..., Z = 35)
+func convertLetterToInt(r rune) (int, error) {
+	number, err := strconv.ParseInt(string(r), 36, 10)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert rune to Number: %s", err)
+	}
+
+	return int(number), nil
+}
+
+// ToNumeric converts an alphanumeric string into its numeric representation
+// by replacing every letter with its corresponding number. (A = 10, B = 11, ..., Z = 35)
+func ToNumeric(s string) (string, error) {
+	var sb strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+			continue
+		}
+
+		number, err := convertLetterToInt(r)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(strconv.Itoa(number))
+	}
+
+	return sb.String(), nil
+}
+
+// CheckDigits computes the two check digits that make the mod97 of s, followed by the check digits, 1.
+// s is usually the reference with its prefix, e.g. the country code or "RF", moved to its end.
+func CheckDigits(s string) (string, error) {
+	numeric, err := ToNumeric(s + "00")
+	if err != nil {
+		return "", err
+	}
+
+	n := 98 - Remainder(numeric)
+	return string([]byte{byte('0' + n/10), byte('0' + n%10)}), nil
+}
+
+// Valid reports whether the mod97 of the alphanumeric s is 1, i.e. whether its check digits are correct.
+func Valid(s string) (bool, error) {
+	numeric, err := ToNumeric(s)
+	if err != nil {
+		return false, err
+	}
+
+	return Remainder(numeric) == 1, nil
+}
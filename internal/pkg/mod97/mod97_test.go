@@ -0,0 +1,131 @@
+package mod97
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemainder(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want uint
+	}{
+		{
+			name: "success three digits",
+			s:    "123",
+			want: 26,
+		},
+		{
+			name: "success 10 digits",
+			s:    "1234567890",
+			want: 2,
+		},
+		{
+			name: "success large number",
+			s:    "3214282912345698765432161182",
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			if got := Remainder(tt.s); got != tt.want {
+				t.Errorf("Remainder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_convertLetterToInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       rune
+		want    int
+		wantErr error
+	}{
+		{
+			name: "success A",
+			r:    'A',
+			want: 10,
+		},
+		{
+			name: "success B",
+			r:    'B',
+			want: 11,
+		},
+		{
+			name: "success Z",
+			r:    'Z',
+			want: 35,
+		},
+		{
+			name: "fails for invalid string",
+			r:    '$',
+			wantErr: fmt.Errorf(
+				"failed to convert rune to Number: strconv.ParseInt: parsing \"$\": invalid syntax",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertLetterToInt(tt.r)
+			require.Equal(t, err, tt.wantErr)
+			if err != nil {
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckDigits(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{name: "IBAN", s: "NWBK60161331926819GB", want: "29"},
+		{name: "RF creditor reference", s: "539007547034RF", want: "18"},
+		{name: "single digit check digits are zero-padded", s: "1RF", want: "74"},
+		{name: "fails for non-alphanumeric characters", s: "$RF", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := CheckDigits(tt.s)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    bool
+		wantErr bool
+	}{
+		{name: "valid IBAN", s: "NWBK60161331926819GB29", want: true},
+		{name: "valid RF creditor reference", s: "539007547034RF18", want: true},
+		{name: "invalid check digits", s: "539007547034RF81"},
+		{name: "fails for non-alphanumeric characters", s: "5390-07547034RF18", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			got, err := Valid(tt.s)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
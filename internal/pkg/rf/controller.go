@@ -0,0 +1,180 @@
+package rf
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	server "github.com/ymakhloufi/pfc/internal/http"
+	"go.uber.org/zap"
+)
+
+var (
+	_ server.Controller = Controller{}
+
+	validateEndpointRegexp = regexp.MustCompile(`^/v1/rf/([^/?]+)/validate/?$`)
+	generateEndpointRegexp = regexp.MustCompile(`^/v1/rf/generate/?$`)
+)
+
+// Parser can parse an RF creditor reference string into a Reference struct, validate it and generate it.
+type Parser interface {
+	Parse(rf string) (Reference, error)
+	Validate(Reference) error
+	Generate(body string) (Reference, error)
+}
+
+// Controller the rf controller that adds routes to the http server.
+type Controller struct {
+	parser Parser
+	logger *zap.Logger
+}
+
+func NewController(parser Parser, logger *zap.Logger) *Controller {
+	return &Controller{
+		parser: parser,
+		logger: logger,
+	}
+}
+
+// SetupRoutes adds the routes to the http server.
+func (ctrl Controller) SetupRoutes() {
+	// handles all routes prefixed with /rf/ (needed to handle non-query route-params)
+	http.HandleFunc("/v1/rf/", func(w http.ResponseWriter, r *http.Request) {
+		// Add sub-routes as new "cases" here.
+		switch path := r.URL.Path; {
+		case r.Method == http.MethodPost && generateEndpointRegexp.MatchString(path): // /rf/generate
+			ctrl.generate(w, r)
+			return
+		case r.Method == http.MethodGet && validateEndpointRegexp.MatchString(path): // /rf/<rf>/validate
+			ctrl.validate(w, r)
+			return
+		default:
+			ctrl.writeResponse(w, newHTTPResponse(nil, fmt.Errorf("unsupported route: %s", path)), http.StatusNotFound)
+		}
+	})
+}
+
+// swagger:operation GET /v1/rf/{rf}/validate validateRF
+//
+// # Validates a given RF creditor reference (ISO 11649) string and returns its validity, components and a possible error message.
+//
+// ---
+// parameters:
+//   - in: path
+//     name: rf
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//    description: RF creditor reference was successfully validated, result can be positive or negative
+//    schema:
+//      $ref: '#/definitions/rfHTTPResponse'
+//	'422':
+//    description: RF creditor reference string could not be parsed, i.e. is not "RF" followed by two digits and 1 to 21 letters and digits
+//    schema:
+//      $ref: '#/definitions/rfHTTPResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// validate parses and validates the rf string.
+func (ctrl Controller) validate(w http.ResponseWriter, r *http.Request) {
+	rfStr := validateEndpointRegexp.FindStringSubmatch(r.URL.Path)[1]
+	rfStr = strings.Replace(rfStr, " ", "", -1)
+	rfStr = strings.ToUpper(rfStr)
+
+	ref, err := ctrl.parser.Parse(rfStr)
+	if err != nil {
+		ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = ctrl.parser.Validate(ref)
+	ctrl.writeResponse(w, newHTTPResponse(&ref, err), http.StatusOK) // failed validation is an expected outcome, thus 200.
+}
+
+// swagger:operation POST /v1/rf/generate generateRF
+//
+// # Generates an RF creditor reference (ISO 11649) from the creditor's own reference, e.g. an invoice number.
+//
+// ---
+// parameters:
+//   - in: body
+//     name: request
+//     required: true
+//     schema:
+//       $ref: '#/definitions/rfGenerateRequest'
+//
+// responses:
+//
+//	'200':
+//    description: RF creditor reference was successfully generated
+//    schema:
+//      $ref: '#/definitions/rfHTTPResponse'
+//	'400':
+//    description: request body is not a valid JSON object
+//    schema:
+//      $ref: '#/definitions/rfHTTPResponse'
+//	'422':
+//    description: the reference is not made up of 1 to 21 letters and digits
+//    schema:
+//      $ref: '#/definitions/rfHTTPResponse'
+//	'500':
+//	  description: Internal Server Error
+
+// generate generates an rf creditor reference from the reference in the request body.
+func (ctrl Controller) generate(w http.ResponseWriter, r *http.Request) {
+	var request generateRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		ctrl.writeResponse(w, newHTTPResponse(nil, fmt.Errorf("failed to decode request body: %w", err)), http.StatusBadRequest)
+		return
+	}
+
+	body := strings.ToUpper(strings.Replace(request.Body, " ", "", -1))
+
+	ref, err := ctrl.parser.Generate(body)
+	if err != nil {
+		ctrl.writeResponse(w, newHTTPResponse(nil, err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ctrl.writeResponse(w, newHTTPResponse(&ref, nil), http.StatusOK)
+}
+
+// newHTTPResponse creates the response for the reference and the error of its processing, if any.
+func newHTTPResponse(ref *Reference, err error) httpResponse {
+	var errStr *string
+	if err != nil {
+		e := err.Error()
+		errStr = &e
+	}
+
+	response := httpResponse{Error: errStr, IsValid: err == nil, Reference: ref}
+	if ref != nil {
+		response.Electronic = ref.String()
+		response.Print = ref.Print()
+	}
+
+	return response
+}
+
+// writeResponse writes the response to the http response writer and logs its outcome.
+func (ctrl Controller) writeResponse(w http.ResponseWriter, response httpResponse, status int) {
+	var reference *string
+	if response.Reference != nil {
+		reference = &response.Electronic
+	}
+
+	l := ctrl.logger.With(
+		zap.Stringp("reference", reference),
+		zap.Stringp("error", response.Error),
+		zap.Int("status", status),
+		zap.Bool("is_valid", response.IsValid),
+	)
+
+	server.WriteJSON(w, l, response, status)
+}
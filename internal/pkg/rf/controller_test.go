@@ -0,0 +1,134 @@
+package rf
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestController_validate(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *http.Request
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/rf/rf18%205390%200754%207034/validate", nil),
+			want: `{"error":null,"is_valid":true,"reference":{"check_digits":"18","body":"539007547034"},"electronic":"RF18539007547034","print":"RF18 5390 0754 7034"}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (Reference, error) {
+					if s != "RF18539007547034" {
+						return Reference{}, errors.New("unexpected rf: " + s)
+					}
+					return Reference{CheckDigits: "18", Body: "539007547034"}, nil
+				},
+				ValidateFunc: func(r Reference) error {
+					return nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "parsing error returns 422",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/rf/RF18/validate", nil),
+			want: `{"error":"parsing error","is_valid":false,"reference":null}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (Reference, error) {
+					return Reference{}, errors.New("parsing error")
+				},
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "validation error returns 200",
+			r:    httptest.NewRequest(http.MethodGet, "/v1/rf/RF81539007547034/validate", nil),
+			want: `{"error":"validation error","is_valid":false,"reference":{"check_digits":"81","body":"539007547034"},"electronic":"RF81539007547034","print":"RF81 5390 0754 7034"}`,
+			parser: &mockParser{
+				ParseFunc: func(s string) (Reference, error) {
+					return Reference{CheckDigits: "81", Body: "539007547034"}, nil
+				},
+				ValidateFunc: func(r Reference) error {
+					return errors.New("validation error")
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.validate(w, tt.r)
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestController_generate(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		parser             Parser
+		want               string
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"body":"inv 2024 000123"}`,
+			want: `{"error":null,"is_valid":true,"reference":{"check_digits":"04","body":"INV2024000123"},"electronic":"RF04INV2024000123","print":"RF04 INV2 0240 0012 3"}`,
+			parser: &mockParser{
+				GenerateFunc: func(body string) (Reference, error) {
+					if body != "INV2024000123" {
+						return Reference{}, errors.New("unexpected body: " + body)
+					}
+					return Reference{CheckDigits: "04", Body: body}, nil
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid request body returns 400",
+			body:               `{"body":`,
+			want:               `{"error":"failed to decode request body: unexpected EOF","is_valid":false,"reference":null}`,
+			parser:             &mockParser{},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "generation error returns 422",
+			body: `{"body":"INV-2024"}`,
+			want: `{"error":"generation error","is_valid":false,"reference":null}`,
+			parser: &mockParser{
+				GenerateFunc: func(body string) (Reference, error) {
+					return Reference{}, errors.New("generation error")
+				},
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			ctrl := Controller{parser: tt.parser, logger: zap.NewNop()}
+
+			w := httptest.NewRecorder()
+			ctrl.generate(w, httptest.NewRequest(http.MethodPost, "/v1/rf/generate", strings.NewReader(tt.body)))
+			require.Equal(t, tt.expectedStatusCode, w.Code)
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
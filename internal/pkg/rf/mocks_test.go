@@ -0,0 +1,31 @@
+package rf
+
+import "testing"
+
+type mockParser struct {
+	t            *testing.T
+	ParseFunc    func(string) (Reference, error)
+	ValidateFunc func(Reference) error
+	GenerateFunc func(string) (Reference, error)
+}
+
+func (p *mockParser) Parse(s string) (Reference, error) {
+	if p.ParseFunc == nil {
+		p.t.Fatalf("mockParser.ParseFunc: method is nil but Parser.Parse was just called")
+	}
+	return p.ParseFunc(s)
+}
+
+func (p *mockParser) Validate(r Reference) error {
+	if p.ValidateFunc == nil {
+		p.t.Fatalf("mockParser.ValidateFunc: method is nil but Parser.Validate was just called")
+	}
+	return p.ValidateFunc(r)
+}
+
+func (p *mockParser) Generate(body string) (Reference, error) {
+	if p.GenerateFunc == nil {
+		p.t.Fatalf("mockParser.GenerateFunc: method is nil but Parser.Generate was just called")
+	}
+	return p.GenerateFunc(body)
+}
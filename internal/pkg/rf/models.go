@@ -0,0 +1,52 @@
+package rf
+
+import "strings"
+
+// swagger:model
+type Reference struct {
+	CheckDigits string `json:"check_digits"`
+	// Body is the creditor's own reference, 1 to 21 letters and digits, e.g. an invoice number.
+	Body string `json:"body"`
+}
+
+// String renders the reference in its electronic form, e.g. "RF18539007547034".
+func (r Reference) String() string {
+	return prefix + r.CheckDigits + r.Body
+}
+
+// Print renders the reference in its print form, in groups of four characters, e.g. "RF18 5390 0754 7034".
+func (r Reference) Print() string {
+	s := r.String()
+
+	var sb strings.Builder
+	for pos := 0; pos < len(s); pos += 4 {
+		if pos > 0 {
+			sb.WriteByte(' ')
+		}
+
+		end := pos + 4
+		if end > len(s) {
+			end = len(s)
+		}
+		sb.WriteString(s[pos:end])
+	}
+
+	return sb.String()
+}
+
+// swagger:model rfHTTPResponse
+type httpResponse struct {
+	Error     *string    `json:"error"`
+	IsValid   bool       `json:"is_valid"`
+	Reference *Reference `json:"reference"`
+
+	// Electronic and Print are the ways to render the reference, only set if it could be parsed.
+	Electronic string `json:"electronic,omitempty"`
+	Print      string `json:"print,omitempty"`
+}
+
+// swagger:model rfGenerateRequest
+type generateRequest struct {
+	// Body is the creditor's own reference to generate the RF creditor reference for, e.g. an invoice number.
+	Body string `json:"body"`
+}
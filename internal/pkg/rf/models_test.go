@@ -0,0 +1,28 @@
+package rf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReference_Print(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  Reference
+		want string
+	}{
+		{name: "full groups", ref: Reference{CheckDigits: "18", Body: "539007547034"}, want: "RF18 5390 0754 7034"},
+		{name: "last group shorter", ref: Reference{CheckDigits: "04", Body: "INV2024000123"}, want: "RF04 INV2 0240 0012 3"},
+		{name: "single character", ref: Reference{CheckDigits: "25", Body: "A"}, want: "RF25 A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.ref.Print())
+			require.Equal(t, "RF"+tt.ref.CheckDigits+tt.ref.Body, tt.ref.String())
+		})
+	}
+}
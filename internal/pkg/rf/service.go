@@ -0,0 +1,77 @@
+package rf
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/ymakhloufi/pfc/internal/pkg/mod97"
+)
+
+// prefix starts every RF creditor reference.
+const prefix = "RF"
+
+var (
+	_ Parser = &Service{}
+
+	// ISO 11649: "RF", two check digits and a reference of 1 to 21 letters and digits
+	rfRegexp               = regexp.MustCompile(`^RF(\d{2})([A-Z\d]{1,21})$`)
+	bodyRegexp             = regexp.MustCompile(`^[A-Z\d]{1,21}$`)
+	ErrIncorrectRFFormat   = fmt.Errorf("provided string does not satisfy the rf creditor reference format: %s", rfRegexp.String())
+	ErrIncorrectBodyFormat = fmt.Errorf("provided reference does not satisfy the format: %s", bodyRegexp.String())
+	ErrIncorrectChecksum   = errors.New("RF creditor reference has the incorrect checksum")
+)
+
+// Service parses, validates and generates RF creditor references (ISO 11649), which use the same ISO 7064
+// mod 97-10 check digits as IBANs.
+type Service struct{}
+
+// NewService creates a new Service.
+func NewService() *Service {
+	return &Service{}
+}
+
+// Parse parses an RF creditor reference in its electronic form into its check digits and body.
+func (svc *Service) Parse(rfStr string) (Reference, error) {
+	matches := rfRegexp.FindStringSubmatch(rfStr)
+	if matches == nil || len(matches) != 3 {
+		return Reference{}, ErrIncorrectRFFormat
+	}
+
+	return Reference{CheckDigits: matches[1], Body: matches[2]}, nil
+}
+
+// Validate checks the reference's check digits.
+// Ref: https://en.wikipedia.org/wiki/Creditor_Reference
+func (svc *Service) Validate(r Reference) error {
+	if !bodyRegexp.MatchString(r.Body) {
+		return ErrIncorrectBodyFormat
+	}
+
+	// like the IBAN, the reference is valid if its mod97 is 1 once the prefix and check digits are moved to the end
+	valid, err := mod97.Valid(r.Body + prefix + r.CheckDigits)
+	if err != nil {
+		return fmt.Errorf("failed to convert RF creditor reference into numeric format: %w", err)
+	}
+
+	if !valid {
+		return ErrIncorrectChecksum
+	}
+
+	return nil
+}
+
+// Generate computes the check digits of the creditor's own reference, e.g. an invoice number,
+// and returns the resulting RF creditor reference.
+func (svc *Service) Generate(body string) (Reference, error) {
+	if !bodyRegexp.MatchString(body) {
+		return Reference{}, ErrIncorrectBodyFormat
+	}
+
+	checkDigits, err := mod97.CheckDigits(body + prefix)
+	if err != nil {
+		return Reference{}, fmt.Errorf("failed to convert RF creditor reference into numeric format: %w", err)
+	}
+
+	return Reference{CheckDigits: checkDigits, Body: body}, nil
+}
@@ -0,0 +1,93 @@
+package rf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rfStr   string
+		want    Reference
+		wantErr error
+	}{
+		{name: "success", rfStr: "RF18539007547034", want: Reference{CheckDigits: "18", Body: "539007547034"}},
+		{name: "letters in body", rfStr: "RF04INV2024000123", want: Reference{CheckDigits: "04", Body: "INV2024000123"}},
+		{name: "longest body", rfStr: "RF40123456789012345678901", want: Reference{CheckDigits: "40", Body: "123456789012345678901"}},
+		{name: "body too long", rfStr: "RF401234567890123456789012", wantErr: ErrIncorrectRFFormat},
+		{name: "missing body", rfStr: "RF18", wantErr: ErrIncorrectRFFormat},
+		{name: "missing prefix", rfStr: "18539007547034", wantErr: ErrIncorrectRFFormat},
+		{name: "letters in check digits", rfStr: "RFXX539007547034", wantErr: ErrIncorrectRFFormat},
+		{name: "lowercase", rfStr: "rf18539007547034", wantErr: ErrIncorrectRFFormat},
+		{name: "empty", rfStr: "", wantErr: ErrIncorrectRFFormat},
+	}
+
+	svc := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			got, err := svc.Parse(tt.rfStr)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     Reference
+		wantErr error
+	}{
+		{name: "success", ref: Reference{CheckDigits: "18", Body: "539007547034"}},
+		{name: "letters in body", ref: Reference{CheckDigits: "04", Body: "INV2024000123"}},
+		{name: "incorrect check digits", ref: Reference{CheckDigits: "81", Body: "539007547034"}, wantErr: ErrIncorrectChecksum},
+		{name: "transposed digits in body", ref: Reference{CheckDigits: "18", Body: "539007457034"}, wantErr: ErrIncorrectChecksum},
+		{name: "empty body", ref: Reference{CheckDigits: "18"}, wantErr: ErrIncorrectBodyFormat},
+	}
+
+	svc := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			require.ErrorIs(t, svc.Validate(tt.ref), tt.wantErr)
+		})
+	}
+}
+
+func TestService_Generate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    Reference
+		wantErr error
+	}{
+		{name: "digits", body: "539007547034", want: Reference{CheckDigits: "18", Body: "539007547034"}},
+		{name: "letters and digits", body: "INV2024000123", want: Reference{CheckDigits: "04", Body: "INV2024000123"}},
+		{name: "single character", body: "A", want: Reference{CheckDigits: "25", Body: "A"}},
+		{name: "too long", body: "1234567890123456789012", wantErr: ErrIncorrectBodyFormat},
+		{name: "separators", body: "INV-2024", wantErr: ErrIncorrectBodyFormat},
+		{name: "empty", body: "", wantErr: ErrIncorrectBodyFormat},
+	}
+
+	svc := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt // shadow tt for parallel execution
+			t.Parallel()
+
+			got, err := svc.Generate(tt.body)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+			if err == nil {
+				require.NoError(t, svc.Validate(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package ukmodulus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Check methods of the modulus weight table.
+const (
+	MethodMod10 = "MOD10"
+	MethodMod11 = "MOD11"
+	MethodDblAl = "DBLAL" // double alternate: sums up the digits of the products
+)
+
+var (
+	ErrInvalidWeightTable       = errors.New("invalid modulus weight table")
+	ErrInvalidSubstitutionTable = errors.New("invalid sort code substitution table")
+	ErrInvalidInput             = errors.New("sort code must have 6 and account number 8 digits")
+	ErrModulusCheckFailed       = errors.New("sort code and account number fail the modulus check")
+)
+
+// Rule is a single row of the modulus weight table (valacdos.txt), applying to a range of sort codes.
+type Rule struct {
+	From      string
+	To        string
+	Method    string
+	Weights   [14]int // for the digits u v w x y z (sort code) a b c d e f g h (account number)
+	Exception int
+}
+
+// Checker validates UK sort code and account number combinations using the modulus checking specification
+// published by Pay.UK (formerly VocaLink).
+// Ref: https://www.vocalink.com/tools/modulus-checking/
+type Checker struct {
+	rules         []Rule
+	substitutions map[string]string
+}
+
+// NewChecker creates a Checker from the rules of the modulus weight table and the sort code substitutions.
+func NewChecker(rules []Rule, substitutions map[string]string) *Checker {
+	return &Checker{rules: rules, substitutions: substitutions}
+}
+
+// Load reads the modulus weight table (valacdos.txt) and the sort code substitution table (scsubtab.txt).
+func Load(weightTable, substitutionTable io.Reader) (*Checker, error) {
+	rules, err := parseWeightTable(weightTable)
+	if err != nil {
+		return nil, err
+	}
+
+	substitutions, err := parseSubstitutionTable(substitutionTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChecker(rules, substitutions), nil
+}
+
+// parseWeightTable parses the whitespace separated rows of the modulus weight table, e.g.
+// "010004 016715 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 1" with an optional exception number at the end.
+func parseWeightTable(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 17 && len(fields) != 18 {
+			return nil, fmt.Errorf("%w: line %d: expected 17 or 18 fields, got %d", ErrInvalidWeightTable, line, len(fields))
+		}
+
+		rule := Rule{From: fields[0], To: fields[1], Method: fields[2]}
+		if !isSortCode(rule.From) || !isSortCode(rule.To) || rule.From > rule.To {
+			return nil, fmt.Errorf("%w: line %d: invalid sort code range", ErrInvalidWeightTable, line)
+		}
+
+		switch rule.Method {
+		case MethodMod10, MethodMod11, MethodDblAl:
+		default:
+			return nil, fmt.Errorf("%w: line %d: unknown method %q", ErrInvalidWeightTable, line, rule.Method)
+		}
+
+		for i := range rule.Weights {
+			weight, err := strconv.Atoi(fields[3+i])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid weight %q", ErrInvalidWeightTable, line, fields[3+i])
+			}
+			rule.Weights[i] = weight
+		}
+
+		if len(fields) == 18 {
+			exception, err := strconv.Atoi(fields[17])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid exception %q", ErrInvalidWeightTable, line, fields[17])
+			}
+			rule.Exception = exception
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read modulus weight table: %w", err)
+	}
+
+	return rules, nil
+}
+
+// parseSubstitutionTable parses the rows of the sort code substitution table, e.g. "938173 938017".
+func parseSubstitutionTable(r io.Reader) (map[string]string, error) {
+	substitutions := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 2 || !isSortCode(fields[0]) || !isSortCode(fields[1]) {
+			return nil, fmt.Errorf("%w: line %d", ErrInvalidSubstitutionTable, line)
+		}
+		substitutions[fields[0]] = fields[1]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sort code substitution table: %w", err)
+	}
+
+	return substitutions, nil
+}
+
+// isSortCode reports whether s consists of exactly six digits.
+func isSortCode(s string) bool {
+	return len(s) == 6 && strings.Trim(s, "0123456789") == ""
+}
+
+// rulesFor returns the rules applying to the sort code, in the order of the weight table.
+func (c *Checker) rulesFor(sortCode string) []Rule {
+	var rules []Rule
+	for _, rule := range c.rules {
+		if rule.From <= sortCode && sortCode <= rule.To {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// Validate checks the combination of the 6-digit sort code and the 8-digit account number.
+// Sort codes that are not covered by the weight table cannot be checked and are considered valid.
+func (c *Checker) Validate(sortCode, accountNumber string) error {
+	if !isSortCode(sortCode) || len(accountNumber) != 8 || strings.Trim(accountNumber, "0123456789") != "" {
+		return ErrInvalidInput
+	}
+
+	rules := c.rulesFor(sortCode)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	first := rules[0]
+	if c.check(first, sortCode, accountNumber) {
+		// a passing first check is sufficient for these exceptions
+		if len(rules) == 1 || first.Exception == 2 || first.Exception == 10 || first.Exception == 12 {
+			return nil
+		}
+	} else if len(rules) == 1 || (first.Exception != 2 && first.Exception != 10 && first.Exception != 12) {
+		return fmt.Errorf("%w: %s check failed", ErrModulusCheckFailed, first.Method)
+	}
+
+	second := rules[1]
+	if second.Exception == 3 && (accountNumber[2] == '6' || accountNumber[2] == '9') {
+		return nil
+	}
+
+	if second.Exception == 9 {
+		// Lloyds euro accounts are held at sort code 30-96-34, but may be quoted with their sterling branch's sort code
+		sortCode = "309634"
+	}
+
+	if !c.check(second, sortCode, accountNumber) {
+		return fmt.Errorf("%w: %s check failed", ErrModulusCheckFailed, second.Method)
+	}
+
+	return nil
+}
+
+// check applies a single rule, including its exception, to the sort code and account number.
+func (c *Checker) check(rule Rule, sortCode, accountNumber string) bool {
+	weights := rule.Weights
+	a, b, g, h := accountNumber[0], accountNumber[1], accountNumber[6], accountNumber[7]
+
+	switch rule.Exception {
+	case 2:
+		if a != '0' && g != '9' {
+			weights = [14]int{0, 0, 1, 2, 5, 3, 6, 4, 8, 7, 10, 9, 3, 1}
+		} else if a != '0' && g == '9' {
+			weights = [14]int{0, 0, 0, 0, 0, 0, 0, 0, 8, 7, 10, 9, 3, 1}
+		}
+	case 5:
+		if substitute, ok := c.substitutions[sortCode]; ok {
+			sortCode = substitute
+		}
+	case 6:
+		// foreign currency accounts cannot be checked
+		if a >= '4' && a <= '8' && g == h {
+			return true
+		}
+	case 7:
+		if g == '9' {
+			zeroiseSortCodeAndAB(&weights)
+		}
+	case 8:
+		sortCode = "090126"
+	case 10:
+		if (a == '0' || a == '9') && b == '9' && g == '9' {
+			zeroiseSortCodeAndAB(&weights)
+		}
+	}
+
+	digits := sortCode + accountNumber
+	sum := 0
+	for i := range weights {
+		product := int(digits[i]-'0') * weights[i]
+		if rule.Method == MethodDblAl {
+			product = product/10 + product%10
+		}
+		sum += product
+	}
+
+	switch {
+	case rule.Exception == 1:
+		return (sum+27)%10 == 0
+	case rule.Exception == 4:
+		// the remainder must equal the two-digit check digit gh
+		return sum%11 == int(g-'0')*10+int(h-'0')
+	case rule.Exception == 5 && rule.Method == MethodMod11:
+		remainder := sum % 11
+		return (remainder == 0 && g == '0') || (remainder > 1 && byte('0'+11-remainder) == g)
+	case rule.Exception == 5 && rule.Method == MethodDblAl:
+		remainder := sum % 10
+		return (remainder == 0 && h == '0') || (remainder > 0 && byte('0'+10-remainder) == h)
+	case rule.Exception == 14 && rule.Method == MethodMod11 && sum%11 != 0:
+		// the account number may have a trailing digit that is not part of the check
+		if h != '0' && h != '1' && h != '9' {
+			return false
+		}
+		shifted := Rule{Method: rule.Method, Weights: rule.Weights}
+		return c.check(shifted, sortCode, "0"+accountNumber[:7])
+	case rule.Method == MethodMod11:
+		return sum%11 == 0
+	default:
+		return sum%10 == 0
+	}
+}
+
+// zeroiseSortCodeAndAB sets the weights of the sort code (u-z) and the first two account digits (a-b) to zero.
+func zeroiseSortCodeAndAB(weights *[14]int) {
+	for i := 0; i < 8; i++ {
+		weights[i] = 0
+	}
+}
@@ -0,0 +1,111 @@
+package ukmodulus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const weightTable = `089999 089999 MOD10    0    0    0    0    0    0    7    1    3    7    1    3    7    1
+107999 107999 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1
+180002 180002 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1   14
+202959 202959 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1
+202959 202959 DBLAL    2    1    2    1    2    1    2    1    2    1    2    1    2    1
+202960 202960 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1   12
+202960 202960 DBLAL    2    1    2    1    2    1    2    1    2    1    2    1    2    1   13
+202961 202961 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1
+202961 202961 DBLAL    2    1    2    1    2    1    2    1    2    1    2    1    2    1    3
+`
+
+func TestChecker_Validate(t *testing.T) {
+	checker, err := Load(strings.NewReader(weightTable), strings.NewReader("938173 938017\n"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		sortCode      string
+		accountNumber string
+		wantErr       error
+	}{
+		{name: "MOD10 valid", sortCode: "089999", accountNumber: "66374958"},
+		{name: "MOD10 invalid", sortCode: "089999", accountNumber: "66374959", wantErr: ErrModulusCheckFailed},
+		{name: "MOD11 valid", sortCode: "107999", accountNumber: "88837491"},
+		{name: "MOD11 invalid", sortCode: "107999", accountNumber: "88837493", wantErr: ErrModulusCheckFailed},
+		{name: "sort code not in weight table", sortCode: "123456", accountNumber: "12345678"},
+		{name: "exception 14 ignores trailing digit", sortCode: "180002", accountNumber: "10000049"},
+		{name: "exception 14 only for trailing 0, 1 or 9", sortCode: "180002", accountNumber: "10000048", wantErr: ErrModulusCheckFailed},
+		{name: "two checks pass", sortCode: "202959", accountNumber: "63748738"},
+		{name: "first check fails", sortCode: "202959", accountNumber: "63748407", wantErr: ErrModulusCheckFailed},
+		{name: "second check fails", sortCode: "202959", accountNumber: "63748401", wantErr: ErrModulusCheckFailed},
+		{name: "exceptions 12 and 13 pass with first check", sortCode: "202960", accountNumber: "63748401"},
+		{name: "exceptions 12 and 13 pass with second check", sortCode: "202960", accountNumber: "63748404"},
+		{name: "exception 3 skips second check for c = 6", sortCode: "202961", accountNumber: "12600008"},
+		{name: "exception 3 applies second check otherwise", sortCode: "202961", accountNumber: "63748401", wantErr: ErrModulusCheckFailed},
+		{name: "non-numeric account number", sortCode: "089999", accountNumber: "6637495A", wantErr: ErrInvalidInput},
+		{name: "short sort code", sortCode: "08999", accountNumber: "66374958", wantErr: ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			err := checker.Validate(tt.sortCode, tt.accountNumber)
+			require.ErrorIs(t, err, tt.wantErr)
+			if err != nil {
+				// errors end up in the logs, which must not contain account data
+				require.NotContains(t, err.Error(), tt.accountNumber)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name              string
+		weightTable       string
+		substitutionTable string
+		wantErr           error
+	}{
+		{
+			name:              "success",
+			weightTable:       weightTable,
+			substitutionTable: "938173 938017\r\n\r\n938289 938068\r\n",
+		},
+		{
+			name:        "missing weights",
+			weightTable: "089999 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7",
+			wantErr:     ErrInvalidWeightTable,
+		},
+		{
+			name:        "unknown method",
+			weightTable: "089999 089999 MOD12 0 0 0 0 0 0 7 1 3 7 1 3 7 1",
+			wantErr:     ErrInvalidWeightTable,
+		},
+		{
+			name:        "reversed sort code range",
+			weightTable: "089999 089998 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1",
+			wantErr:     ErrInvalidWeightTable,
+		},
+		{
+			name:        "invalid exception",
+			weightTable: "089999 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1 X",
+			wantErr:     ErrInvalidWeightTable,
+		},
+		{
+			name:              "invalid substitution",
+			weightTable:       weightTable,
+			substitutionTable: "938173",
+			wantErr:           ErrInvalidSubstitutionTable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt := tt
+			t.Parallel()
+
+			_, err := Load(strings.NewReader(tt.weightTable), strings.NewReader(tt.substitutionTable))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}